@@ -0,0 +1,146 @@
+/*
+ * build.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"os"
+)
+
+// Subtrees of the root built from at least this many strings are built in their own goroutines by
+// BuildFromSorted().
+const parallelBuildThreshold = 4096
+
+// Internal function: builds the node for a group of strings which share their first depth runes.  The
+// strings are sorted, so any equal to the shared prefix come first, and the rest are grouped by the rune
+// which follows it.
+func buildNode(keys [][]int, values []interface{}, depth int, parallel bool) *Trie {
+	p := new(Trie)
+	for len(keys) != 0 && len(keys[0]) == depth {
+		// a repeated string takes the last of its values
+		p.leaf = true
+		p.value, p.valued = values[0], true
+		keys, values = keys[1:], values[1:]
+	}
+	if p.leaf {
+		p.count = 1
+	}
+
+	// count the groups first, so that the map can be allocated at its final size
+	groups := 0
+	for i := 0; i < len(keys); i++ {
+		if i == 0 || keys[i][depth] != keys[i-1][depth] {
+			groups++
+		}
+	}
+	p.children = make(map[int]*Trie, groups)
+
+	runes := make([]int, 0, groups)
+	children := make([]*Trie, groups)
+	done := make(chan bool, groups)
+	spawned := 0
+	for start := 0; start < len(keys); {
+		end := start + 1
+		for end < len(keys) && keys[end][depth] == keys[start][depth] {
+			end++
+		}
+
+		i := len(runes)
+		runes = runes[0 : i+1]
+		runes[i] = keys[start][depth]
+		if parallel && end-start >= parallelBuildThreshold {
+			go func(keys [][]int, values []interface{}) {
+				children[i] = buildNode(keys, values, depth+1, false)
+				done <- true
+			}(keys[start:end], values[start:end])
+			spawned++
+		} else {
+			children[i] = buildNode(keys[start:end], values[start:end], depth+1, false)
+		}
+		start = end
+	}
+
+	for ; spawned > 0; spawned-- {
+		<-done
+	}
+	p.runes = runes
+	for i, rune := range runes {
+		p.children[rune] = children[i]
+		p.count += children[i].count
+	}
+	p.rebuildCounts()
+	return p
+}
+
+// Creates and returns a new Trie containing the strings and values produced by the given iterator, which
+// behaves as those returned by All().  The strings must be in ascending rune order; a repeated string
+// takes the last of its values.  Because the strings are sorted, each node is built only once, with
+// storage for exactly the children it needs, and the root's largest subtrees are built in parallel.
+func BuildFromSorted(iter func(yield func(key string, value interface{}) bool)) (*Trie, os.Error) {
+	keys := make([][]int, 0, 64)
+	values := make([]interface{}, 0, 64)
+	var err os.Error
+	last := ``
+
+	iter(func(key string, value interface{}) bool {
+		if len(keys) != 0 && key < last {
+			err = os.NewError(`key '` + key + `' is out of order after '` + last + `'`)
+			return false
+		}
+		last = key
+
+		if len(keys) == cap(keys) {
+			k := make([][]int, len(keys), 2*cap(keys))
+			copy(k, keys)
+			keys = k
+			v := make([]interface{}, len(values), 2*cap(values))
+			copy(v, values)
+			values = v
+		}
+		keys = keys[0 : len(keys)+1]
+		keys[len(keys)-1] = []int(key)
+		values = values[0 : len(values)+1]
+		values[len(values)-1] = value
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return buildNode(keys, values, 0, true), nil
+}
@@ -0,0 +1,158 @@
+/*
+ * build_test.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"testing"
+	"fmt"
+)
+
+// Returns an iterator over the given keys, with their indices as values, as All().
+func sliceIterator(keys []string) func(yield func(key string, value interface{}) bool) {
+	return func(yield func(string, interface{}) bool) {
+		for i, key := range keys {
+			if !yield(key, i) {
+				return
+			}
+		}
+	}
+}
+
+func TestBuildFromSorted(t *testing.T) {
+	keys := []string{``, `inn`, `te`, `tea`, `tea`, `ten`, `to`, `té`}
+	trie, err := BuildFromSorted(sliceIterator(keys))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := NewTrie()
+	for i, key := range keys {
+		expected.AddValue(key, i)
+	}
+
+	if trie.Len() != expected.Len() || trie.Size() != expected.Size() {
+		t.Errorf("Expected %d members in %d nodes, got %d in %d", expected.Len(), expected.Size(),
+			trie.Len(), trie.Size())
+	}
+	expected.All()(func(key string, value interface{}) bool {
+		if v, ok := trie.GetValue(key); !ok || v != value {
+			t.Errorf("Expected '%s' to have value %v, got %v/%v", key, value, v, ok)
+		}
+		if !trie.HasValue(key) {
+			t.Errorf("Expected '%s' to be built with a value", key)
+		}
+		return true
+	})
+	if n := trie.CountPrefix(`te`); n != 3 {
+		t.Errorf("Expected 3 members beginning with 'te', got %d", n)
+	}
+
+	if _, err := BuildFromSorted(sliceIterator([]string{`b`, `a`})); err == nil {
+		t.Error("Expected an error for unsorted keys")
+	}
+}
+
+func TestBuildFromSortedParallel(t *testing.T) {
+	keys := make([]string, 3*parallelBuildThreshold)
+	for i := 0; i < len(keys); i++ {
+		keys[i] = fmt.Sprintf("%c%06d", 'a'+i/parallelBuildThreshold, i)
+	}
+
+	trie, err := BuildFromSorted(sliceIterator(keys))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if trie.Len() != len(keys) {
+		t.Errorf("Expected %d members, got %d", len(keys), trie.Len())
+	}
+	for _, i := range []int{0, parallelBuildThreshold, len(keys) - 1} {
+		if key, value, _ := trie.Select(i); key != keys[i] || value != i {
+			t.Errorf("Expected member %d to be %s/%d, got %s/%v", i, keys[i], i, key, value)
+		}
+	}
+}
+
+// Benchmarks, using the patterns read from Stdin as for BenchmarkTraversal.
+
+func benchmarkKeys() ([]string, []interface{}) {
+	trie := setupTrie()
+	if trie == nil {
+		return nil, nil
+	}
+
+	keys := make([]string, trie.Len())
+	values := make([]interface{}, trie.Len())
+	i := 0
+	trie.All()(func(key string, value interface{}) bool {
+		keys[i], values[i] = key, value
+		i++
+		return true
+	})
+	return keys, values
+}
+
+func BenchmarkBuildFromSorted(b *testing.B) {
+	b.StopTimer()
+	keys, values := benchmarkKeys()
+	iter := func(yield func(string, interface{}) bool) {
+		for i, key := range keys {
+			if !yield(key, values[i]) {
+				return
+			}
+		}
+	}
+	b.StartTimer()
+
+	for i := 0; i < b.N; i++ {
+		BuildFromSorted(iter)
+	}
+}
+
+func BenchmarkAddValue(b *testing.B) {
+	b.StopTimer()
+	keys, values := benchmarkKeys()
+	b.StartTimer()
+
+	for i := 0; i < b.N; i++ {
+		trie := NewTrie()
+		for j, key := range keys {
+			trie.AddValue(key, values[j])
+		}
+	}
+}
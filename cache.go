@@ -0,0 +1,146 @@
+/*
+ * cache.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"container/list"
+	"container/vector"
+	"sync"
+)
+
+// A BreakCache remembers the breaks found in recently hyphenated words, discarding the least recently
+// used once it reaches its capacity.  It is safe for concurrent use.
+type BreakCache struct {
+	lock     sync.Mutex
+	capacity int
+	entries  map[string]*list.Element // the elements of order, keyed by word.
+	order    *list.List               // values of type *cacheEntry, most recently used first.
+	hits     int
+	misses   int
+}
+
+// An entry in a BreakCache.
+type cacheEntry struct {
+	word   string
+	breaks *vector.Vector
+}
+
+// Creates and returns a new BreakCache holding the breaks of at most capacity words.
+func NewBreakCache(capacity int) *BreakCache {
+	c := new(BreakCache)
+	c.capacity = capacity
+	c.entries = make(map[string]*list.Element)
+	c.order = list.New()
+	return c
+}
+
+// Internal function: copies a vector of breaks, so the cached copy can't be changed by callers.
+func copyBreaks(breaks *vector.Vector) *vector.Vector {
+	v := new(vector.Vector)
+	for i := 0; i < breaks.Len(); i++ {
+		b := *breaks.At(i).(*Break)
+		v.Push(&b)
+	}
+	return v
+}
+
+// Internal function: returns a copy of the breaks cached for a word, if any.
+func (c *BreakCache) get(word string) (*vector.Vector, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	e, ok := c.entries[word]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+
+	c.hits++
+	c.order.MoveToFront(e)
+	return copyBreaks(e.Value.(*cacheEntry).breaks), true
+}
+
+// Internal function: caches a copy of the breaks for a word, evicting the least recently used word if
+// the cache is full.
+func (c *BreakCache) put(word string, breaks *vector.Vector) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.capacity <= 0 {
+		return
+	}
+	if e, ok := c.entries[word]; ok {
+		e.Value.(*cacheEntry).breaks = copyBreaks(breaks)
+		c.order.MoveToFront(e)
+		return
+	}
+
+	for c.order.Len() >= c.capacity {
+		last := c.order.Back()
+		c.entries[last.Value.(*cacheEntry).word] = nil, false
+		c.order.Remove(last)
+	}
+	c.entries[word] = c.order.PushFront(&cacheEntry{word, copyBreaks(breaks)})
+}
+
+// Returns the number of words currently cached.
+func (c *BreakCache) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.order.Len()
+}
+
+// Returns the number of lookups which found and didn't find a cached word.
+func (c *BreakCache) Stats() (hits, misses int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.hits, c.misses
+}
+
+// Discards every cached word and resets the statistics.  This should be called after changing the
+// settings of a Hyphenator using the cache.
+func (c *BreakCache) Clear() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+	c.hits = 0
+	c.misses = 0
+}
@@ -0,0 +1,98 @@
+/*
+ * cache_test.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"testing"
+)
+
+func TestBreakCache(t *testing.T) {
+	patterns := NewTrie()
+	patterns.AddPatternString(`hy3ph`)
+	patterns.AddPatternString(`hen5at`)
+
+	h := NewHyphenator(patterns)
+	h.Cache = NewBreakCache(2)
+
+	h.Breaks(`hyphenation`)
+	breaks := h.Breaks(`hyphenation`)
+	if hits, misses := h.Cache.Stats(); hits != 1 || misses != 1 {
+		t.Errorf("Expected 1 hit and 1 miss, got %d and %d", hits, misses)
+	}
+
+	// changing the result mustn't change the cached copy
+	breaks.At(0).(*Break).Offset = 100
+	breaks.Cut(1, breaks.Len())
+	breaks = h.Breaks(`hyphenation`)
+	if breaks.Len() != 2 || breaks.At(0).(*Break).Offset != 2 {
+		t.Error("The cached breaks were changed through a returned vector")
+	}
+
+	h.Breaks(`hyphen`)
+	h.Breaks(`henat`)
+	if h.Cache.Len() != 2 {
+		t.Errorf("Expected the cache to hold 2 words, got %d", h.Cache.Len())
+	}
+
+	// 'hyphenation' is the least recently used, so should have been evicted
+	h.Breaks(`hyphenation`)
+	if hits, misses := h.Cache.Stats(); hits != 2 || misses != 4 {
+		t.Errorf("Expected 2 hits and 4 misses, got %d and %d", hits, misses)
+	}
+
+	done := make(chan bool)
+	for i := 0; i < 4; i++ {
+		go func() {
+			for j := 0; j < 100; j++ {
+				if h.Hyphenate(`hyphenation`, `-`) != `hy-phen-ation` {
+					t.Error("Concurrent hyphenation gave the wrong result")
+				}
+			}
+			done <- true
+		}()
+	}
+	for i := 0; i < 4; i++ {
+		<-done
+	}
+
+	h.Cache.Clear()
+	if hits, misses := h.Cache.Stats(); h.Cache.Len() != 0 || hits != 0 || misses != 0 {
+		t.Error("Clearing the cache should discard all words and statistics")
+	}
+}
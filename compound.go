@@ -0,0 +1,132 @@
+/*
+ * compound.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"container/vector"
+	"utf8"
+)
+
+// A Compound describes the hyphenation of a word made up of words from a lexicon.
+type Compound struct {
+	Word       string
+	Components *vector.StringVector // the lexicon words making up the word, or just the word itself.
+	Boundaries *vector.IntVector    // rune offsets of the boundaries between components.
+	Points     *vector.IntVector    // rune offsets of all hyphenation points, including the boundaries.
+}
+
+// Returns the word with the given hyphen string inserted at each of its hyphenation points.
+func (c *Compound) Hyphenate(hyphen string) string {
+	return insertHyphens(c.Word, c.Points, hyphen)
+}
+
+// Splits a word into the fewest possible words from this trie, each of at least minComponent runes.
+// Where several splits have the fewest components, the one with the longest leading components is
+// chosen.  Returns nil if the word can't be made entirely from words in the trie.
+func (p *Trie) SegmentCompound(word string, minComponent int) *vector.StringVector {
+	// best[i] holds the byte length of the first component of the best split of word[i:], and count[i]
+	// the number of components in that split; a count of zero means there's no split.
+	best := make([]int, len(word)+1)
+	count := make([]int, len(word)+1)
+
+	// work backwards, so each suffix is solved before any prefix which reaches it
+	for i := len(word) - 1; i >= 0; i-- {
+		if !utf8.RuneStart(word[i]) {
+			continue
+		}
+
+		prefixes := p.AllSubstrings(word[i:])
+		for j := 0; j < prefixes.Len(); j++ {
+			prefix := prefixes.At(j)
+			end := i + len(prefix)
+			if utf8.RuneCountInString(prefix) < minComponent {
+				continue
+			}
+			if end != len(word) && count[end] == 0 {
+				continue
+			}
+
+			// prefixes get longer as we go, so ties favour the later ones
+			if n := count[end] + 1; count[i] == 0 || n <= count[i] {
+				best[i] = len(prefix)
+				count[i] = n
+			}
+		}
+	}
+
+	if count[0] == 0 {
+		return nil
+	}
+
+	components := new(vector.StringVector)
+	for i := 0; i < len(word); i += best[i] {
+		components.Push(word[i : i+best[i]])
+	}
+	return components
+}
+
+// Hyphenates a compound word.  The word is first split into components using the words in the given
+// lexicon, as by SegmentCompound(); the boundaries between components are always hyphenation points,
+// and the patterns in this trie are then applied to each component as a word in its own right.  A word
+// which can't be split is hyphenated as a single component.
+func (p *Trie) HyphenateCompound(word string, lexicon *Trie, minComponent, leftMin, rightMin int) *Compound {
+	c := &Compound{word, lexicon.SegmentCompound(word, minComponent), new(vector.IntVector),
+		new(vector.IntVector)}
+	if c.Components == nil {
+		c.Components = new(vector.StringVector)
+		c.Components.Push(word)
+	}
+
+	offset := 0
+	for i := 0; i < c.Components.Len(); i++ {
+		component := c.Components.At(i)
+		if i != 0 {
+			c.Boundaries.Push(offset)
+			c.Points.Push(offset)
+		}
+
+		points := p.HyphenationPoints(component, leftMin, rightMin)
+		for j := 0; j < points.Len(); j++ {
+			c.Points.Push(offset + points.At(j))
+		}
+		offset += utf8.RuneCountInString(component)
+	}
+
+	return c
+}
@@ -0,0 +1,78 @@
+/*
+ * compound_test.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"testing"
+)
+
+func TestCompound(t *testing.T) {
+	lexicon := NewTrie()
+	lexicon.AddString(`haus`)
+	lexicon.AddString(`tür`)
+	lexicon.AddString(`haustür`)
+	lexicon.AddString(`schlüssel`)
+	lexicon.AddString(`el`)
+
+	prefixes := lexicon.AllSubstrings(`haustürschlüssel`)
+	if prefixes.Len() != 2 || prefixes.At(0) != `haus` || prefixes.At(1) != `haustür` {
+		t.Errorf("Expected prefixes [haus haustür], got %v", *prefixes)
+	}
+
+	components := lexicon.SegmentCompound(`haustürschlüssel`, 3)
+	if components == nil || components.Len() != 2 || components.At(0) != `haustür` {
+		t.Fatalf("Expected components [haustür schlüssel], got %v", components)
+	}
+	if lexicon.SegmentCompound(`haustürmatte`, 3) != nil {
+		t.Error("A word not made entirely from the lexicon shouldn't be segmented")
+	}
+
+	patterns := NewTrie()
+	patterns.AddPatternString(`s1t`)
+	patterns.AddPatternString(`s1s`)
+
+	c := patterns.HyphenateCompound(`haustürschlüssel`, lexicon, 3, DefaultLeftHyphenMin,
+		DefaultRightHyphenMin)
+	if c.Boundaries.Len() != 1 || c.Boundaries.At(0) != 7 {
+		t.Errorf("Expected a compound boundary at offset 7, got %v", *c.Boundaries)
+	}
+	if s := c.Hyphenate(`-`); s != `haus-tür-schlüs-sel` {
+		t.Errorf("Expected 'haus-tür-schlüs-sel', got '%s'", s)
+	}
+}
@@ -0,0 +1,144 @@
+/*
+ * cursor.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"container/vector"
+)
+
+// A Cursor walks a trie one rune at a time, remembering the path it has taken so that it can step back
+// without descending again from the root.  The trie may be read while a cursor is held; if strings are
+// removed, a cursor positioned below the removal point may refer to nodes no longer in the trie.
+type Cursor struct {
+	path  []*Trie // the nodes visited, beginning with the root.
+	runes []int   // the rune used to step to each node after the root.
+}
+
+// Returns a new Cursor positioned at the root of the trie.
+func (p *Trie) Cursor() *Cursor {
+	c := new(Cursor)
+	c.path = []*Trie{p}
+	c.runes = []int{}
+	return c
+}
+
+// Internal function: returns the node at which the cursor is positioned.
+func (c *Cursor) current() *Trie {
+	return c.path[len(c.path)-1]
+}
+
+// Moves the cursor to the child for the given rune.  Returns false, leaving the cursor where it was, if
+// no member string continues with that rune.
+func (c *Cursor) Step(rune int) bool {
+	child, ok := c.current().children[rune]
+	if !ok {
+		return false
+	}
+
+	// the path holds the root as well, so each slice is grown against its own capacity
+	if len(c.path) == cap(c.path) {
+		path := make([]*Trie, len(c.path), 2*cap(c.path)+1)
+		copy(path, c.path)
+		c.path = path
+	}
+	if len(c.runes) == cap(c.runes) {
+		runes := make([]int, len(c.runes), 2*cap(c.runes)+1)
+		copy(runes, c.runes)
+		c.runes = runes
+	}
+	c.path = c.path[0 : len(c.path)+1]
+	c.path[len(c.path)-1] = child
+	c.runes = c.runes[0 : len(c.runes)+1]
+	c.runes[len(c.runes)-1] = rune
+	return true
+}
+
+// Moves the cursor back to the parent of its current node.  Returns false if the cursor is already at
+// the root.
+func (c *Cursor) Back() bool {
+	if len(c.path) == 1 {
+		return false
+	}
+	c.path[len(c.path)-1] = nil
+	c.path = c.path[0 : len(c.path)-1]
+	c.runes = c.runes[0 : len(c.runes)-1]
+	return true
+}
+
+// Returns true if the runes stepped through so far form a member string.
+func (c *Cursor) IsLeaf() bool {
+	return c.current().leaf
+}
+
+// Returns the value associated with the current member string, if any.
+func (c *Cursor) Value() (interface{}, bool) {
+	n := c.current()
+	if !n.leaf {
+		return nil, false
+	}
+	return n.value, true
+}
+
+// Returns the runes which may follow the cursor's current position, in ascending order.
+func (c *Cursor) Children() *vector.IntVector {
+	runes := c.current().runes
+	children := make(vector.IntVector, len(runes))
+	copy(children, runes)
+	return &children
+}
+
+// Returns the string stepped through so far.
+func (c *Cursor) Key() string {
+	return string(c.runes)
+}
+
+// Returns the number of runes stepped through so far.
+func (c *Cursor) Depth() int {
+	return len(c.runes)
+}
+
+// Returns an independent copy of the cursor, at the same position.
+func (c *Cursor) Clone() *Cursor {
+	clone := new(Cursor)
+	clone.path = make([]*Trie, len(c.path), cap(c.path))
+	copy(clone.path, c.path)
+	clone.runes = make([]int, len(c.runes), cap(c.runes))
+	copy(clone.runes, c.runes)
+	return clone
+}
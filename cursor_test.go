@@ -0,0 +1,124 @@
+/*
+ * cursor_test.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"testing"
+)
+
+func TestCursor(t *testing.T) {
+	trie := NewTrie()
+	trie.AddValue(`tea`, 1)
+	trie.AddValue(`ten`, 2)
+	trie.AddString(`te`)
+
+	c := trie.Cursor()
+	if c.Back() {
+		t.Error("Expected Back() at the root to fail")
+	}
+	if c.Step('x') {
+		t.Error("Expected Step('x') to fail")
+	}
+	for _, rune := range `te` {
+		if !c.Step(rune) {
+			t.Fatalf("Expected Step('%c') to succeed", rune)
+		}
+	}
+	if !c.IsLeaf() || c.Key() != `te` || c.Depth() != 2 {
+		t.Errorf("Expected the cursor to be at leaf 'te', got '%s'", c.Key())
+	}
+	if value, ok := c.Value(); !ok || value != nil {
+		t.Errorf("Expected value <nil>, got %v/%v", value, ok)
+	}
+	children := c.Children()
+	if children.Len() != 2 || children.At(0) != 'a' || children.At(1) != 'n' {
+		t.Errorf("Expected children [a n], got %v", *children)
+	}
+
+	clone := c.Clone()
+	if !clone.Step('n') {
+		t.Fatal("Expected the clone to step to 'ten'")
+	}
+	if value, ok := clone.Value(); !ok || value != 2 {
+		t.Errorf("Expected value 2 for 'ten', got %v/%v", value, ok)
+	}
+	if c.Key() != `te` {
+		t.Errorf("Expected the original cursor to stay at 'te', got '%s'", c.Key())
+	}
+
+	if !c.Step('a') || !c.Back() || !c.Back() {
+		t.Fatal("Expected to step forward and back")
+	}
+	if c.IsLeaf() || c.Key() != `t` {
+		t.Errorf("Expected the cursor to be at non-leaf 't', got '%s'", c.Key())
+	}
+	if _, ok := c.Value(); ok {
+		t.Error("Expected no value at 't'")
+	}
+}
+
+func TestCursorDeep(t *testing.T) {
+	trie := NewTrie()
+	trie.AddValue(`abcdefgh`, 1)
+	trie.AddValue(`abcdefgx`, 2)
+
+	c := trie.Cursor()
+	for _, rune := range `abcdef` {
+		if !c.Step(rune) {
+			t.Fatalf("Expected Step('%c') to succeed", rune)
+		}
+	}
+
+	clone := c.Clone()
+	for _, rune := range `gh` {
+		if !clone.Step(rune) {
+			t.Fatalf("Expected the clone to Step('%c')", rune)
+		}
+	}
+	if value, ok := clone.Value(); !ok || value != 1 || clone.Key() != `abcdefgh` || clone.Depth() != 8 {
+		t.Errorf("Expected the clone to be at 'abcdefgh' with value 1, got '%s' with %v", clone.Key(), value)
+	}
+
+	if !c.Step('g') || !c.Step('x') {
+		t.Fatal("Expected the original cursor to step to 'abcdefgx'")
+	}
+	if value, ok := c.Value(); !ok || value != 2 || c.Key() != `abcdefgx` {
+		t.Errorf("Expected the cursor to be at 'abcdefgx' with value 2, got '%s' with %v", c.Key(), value)
+	}
+}
@@ -0,0 +1,205 @@
+/*
+ * evaluate.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"bufio"
+	"container/vector"
+	"io"
+	"os"
+	"strings"
+)
+
+// A WordDiff describes the differences between the reference hyphenation of a word and the one
+// produced by a pattern trie.
+type WordDiff struct {
+	Word     string            // the word, without hyphens.
+	Expected string            // the reference hyphenation, e.g. 'as-so-ciate'.
+	Found    string            // the hyphenation produced by the patterns.
+	Missed   *vector.IntVector // rune offsets of reference breaks which weren't found.
+	Spurious *vector.IntVector // rune offsets of breaks found which aren't in the reference.
+
+	// The patterns responsible for each missed or spurious break, keyed by rune offset.  For a
+	// spurious break these are the patterns supplying the winning odd value; for a missed break they
+	// are the patterns supplying the winning even value, and there are none if no pattern covered it.
+	Culprits map[int]*vector.StringVector
+}
+
+// An Evaluation summarizes the quality of a pattern set against a reference list of hyphenated words.
+type Evaluation struct {
+	Words          int            // the number of words evaluated.
+	TruePositives  int            // breaks found which are in the reference.
+	FalsePositives int            // breaks found which aren't in the reference.
+	FalseNegatives int            // breaks in the reference which weren't found.
+	Diffs          *vector.Vector // elements of type *WordDiff, one for each word hyphenated incorrectly.
+}
+
+// The proportion of breaks found which are correct.  A pattern set which finds no breaks has a precision of 1.
+func (e *Evaluation) Precision() float64 {
+	found := e.TruePositives + e.FalsePositives
+	if found == 0 {
+		return 1
+	}
+	return float64(e.TruePositives) / float64(found)
+}
+
+// The proportion of reference breaks which were found.  An empty reference gives a recall of 1.
+func (e *Evaluation) Recall() float64 {
+	expected := e.TruePositives + e.FalseNegatives
+	if expected == 0 {
+		return 1
+	}
+	return float64(e.TruePositives) / float64(expected)
+}
+
+// The harmonic mean of precision and recall.
+func (e *Evaluation) F1() float64 {
+	p, r := e.Precision(), e.Recall()
+	if p+r == 0 {
+		return 0
+	}
+	return 2 * p * r / (p + r)
+}
+
+// Internal function: splits a reference hyphenation such as 'as-so-ciate' into its plain word and
+// the rune offsets of its breaks.
+func parseReference(s string) (string, *vector.IntVector) {
+	points := new(vector.IntVector)
+	word := strings.Map(func(rune int) int {
+		if rune == '-' {
+			return -1
+		}
+		return rune
+	},
+		s)
+
+	i := 0
+	for _, rune := range s {
+		if rune == '-' {
+			points.Push(i)
+		} else {
+			i++
+		}
+	}
+
+	return word, points
+}
+
+// Internal function: returns the patterns which supply the winning value at the given word offset.
+func culprits(matches *vector.Vector, offset int) *vector.StringVector {
+	patterns := new(vector.StringVector)
+
+	// account for the leading dot
+	_, winners := winningMatches(matches, offset+1)
+	for i := 0; i < winners.Len(); i++ {
+		patterns.Push(winners.At(i).(*PatternMatch).String())
+	}
+	return patterns
+}
+
+// Compares the hyphenation of a single word against its reference form (e.g. 'as-so-ciate'), adding
+// the results to the evaluation.
+func (e *Evaluation) addWord(p *Trie, reference string, leftMin, rightMin int) {
+	word, expected := parseReference(reference)
+	found := p.HyphenationPoints(word, leftMin, rightMin)
+
+	diff := &WordDiff{word, reference, insertHyphens(word, found, `-`), new(vector.IntVector),
+		new(vector.IntVector), make(map[int]*vector.StringVector)}
+
+	// both sets of points are sorted, so walk them together
+	i, j := 0, 0
+	for i < expected.Len() || j < found.Len() {
+		switch {
+		case j == found.Len() || (i < expected.Len() && expected.At(i) < found.At(j)):
+			diff.Missed.Push(expected.At(i))
+			i++
+		case i == expected.Len() || found.At(j) < expected.At(i):
+			diff.Spurious.Push(found.At(j))
+			j++
+		default:
+			e.TruePositives++
+			i++
+			j++
+		}
+	}
+
+	e.Words++
+	e.FalseNegatives += diff.Missed.Len()
+	e.FalsePositives += diff.Spurious.Len()
+	if diff.Missed.Len() == 0 && diff.Spurious.Len() == 0 {
+		return
+	}
+
+	matches := p.matchPatterns(`.` + word + `.`)
+	for _, offset := range diff.Missed.Data() {
+		diff.Culprits[offset] = culprits(matches, offset)
+	}
+	for _, offset := range diff.Spurious.Data() {
+		diff.Culprits[offset] = culprits(matches, offset)
+	}
+	e.Diffs.Push(diff)
+}
+
+// Hyphenates every word of a reference list using the patterns in the trie, and reports how closely
+// the results match.  The list contains whitespace-separated words with their correct breaks marked
+// by hyphens, e.g. 'as-so-ciate'; lines beginning with '%' or '#' are treated as comments.
+func (p *Trie) Evaluate(r io.Reader, leftMin, rightMin int) (*Evaluation, os.Error) {
+	e := &Evaluation{Diffs: new(vector.Vector)}
+	reader := bufio.NewReader(r)
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && err != os.EOF {
+			return nil, err
+		}
+
+		line = strings.TrimSpace(line)
+		if len(line) != 0 && line[0] != '%' && line[0] != '#' {
+			for _, reference := range strings.Fields(line) {
+				e.addWord(p, reference, leftMin, rightMin)
+			}
+		}
+
+		if err == os.EOF {
+			break
+		}
+	}
+
+	return e, nil
+}
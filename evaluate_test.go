@@ -0,0 +1,101 @@
+/*
+ * evaluate_test.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"testing"
+	"strings"
+)
+
+func TestEvaluate(t *testing.T) {
+	trie := NewTrie()
+	trie.AddPatternString(`hy3phe2n5a4t2io2n`)
+	trie.AddPatternString(`a1b`)
+
+	gold := "% a comment line\nhy-phen-a-tion\n\ntable\n"
+	e, err := trie.Evaluate(strings.NewReader(gold), DefaultLeftHyphenMin, DefaultRightHyphenMin)
+	if err != nil {
+		t.Fatalf("Evaluation failed: %s", err)
+	}
+
+	if e.Words != 2 {
+		t.Errorf("Expected 2 words to be evaluated, got %d", e.Words)
+	}
+	if e.TruePositives != 2 || e.FalsePositives != 1 || e.FalseNegatives != 1 {
+		t.Errorf("Expected 2/1/1 true positives/false positives/false negatives, got %d/%d/%d",
+			e.TruePositives, e.FalsePositives, e.FalseNegatives)
+	}
+	if e.Precision() != 2.0/3.0 || e.Recall() != 2.0/3.0 {
+		t.Errorf("Expected precision and recall of 2/3, got %v and %v", e.Precision(), e.Recall())
+	}
+	if e.Diffs.Len() != 2 {
+		t.Fatalf("Expected 2 word diffs, got %d", e.Diffs.Len())
+	}
+
+	diff := e.Diffs.At(0).(*WordDiff)
+	if diff.Found != `hy-phen-ation` {
+		t.Errorf("Expected 'hy-phen-ation', got '%s'", diff.Found)
+	}
+	if diff.Missed.Len() != 1 || diff.Missed.At(0) != 7 {
+		t.Errorf("Expected a missed break at offset 7, got %v", *diff.Missed)
+	}
+	if c := diff.Culprits[7]; c == nil || c.Len() != 1 || c.At(0) != `hy3phe2n5a4t2io2n` {
+		t.Errorf("Expected 'hy3phe2n5a4t2io2n' to be blamed for the missed break, got %v", c)
+	}
+
+	diff = e.Diffs.At(1).(*WordDiff)
+	if diff.Spurious.Len() != 1 || diff.Spurious.At(0) != 2 {
+		t.Errorf("Expected a spurious break at offset 2, got %v", *diff.Spurious)
+	}
+	if c := diff.Culprits[2]; c == nil || c.Len() != 1 || c.At(0) != `a1b` {
+		t.Errorf("Expected 'a1b' to be blamed for the spurious break, got %v", c)
+	}
+
+	// a pattern covering a missed break without a digit there is blamed for its implied 0
+	trie = NewTrie()
+	trie.AddPatternString(`bc1d`)
+	e, err = trie.Evaluate(strings.NewReader("ab-cdef\n"), DefaultLeftHyphenMin, DefaultRightHyphenMin)
+	if err != nil {
+		t.Fatalf("Evaluation failed: %s", err)
+	}
+	diff = e.Diffs.At(0).(*WordDiff)
+	if c := diff.Culprits[2]; c == nil || c.Len() != 1 || c.At(0) != `bc1d` {
+		t.Errorf("Expected 'bc1d' to be blamed for the missed break, got %v", c)
+	}
+}
@@ -0,0 +1,178 @@
+/*
+ * explain.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"bytes"
+	"container/vector"
+	"io"
+	"os"
+	"strconv"
+	"utf8"
+)
+
+// An Explanation describes how the patterns in a trie arrived at the hyphenation of a word.
+type Explanation struct {
+	Word    string            // the word which was hyphenated.
+	Matches *vector.Vector    // every matching pattern, of type *PatternMatch, in order of offset.
+	Values  *vector.IntVector // the value at each position of the word, as from HyphenationValues().
+	Winners *vector.Vector    // the *PatternMatch supplying each value, or nil where no pattern covered it.
+	Points  *vector.IntVector // the resulting hyphenation points, as from HyphenationPoints().
+}
+
+// Internal function: returns the largest value given to a gap of the dotted word by any of the
+// matches, along with every match which supplies it.  A match covering the gap without a digit there
+// supplies a value of 0, so the matches are empty only if none covers the gap.
+func winningMatches(matches *vector.Vector, gap int) (int, *vector.Vector) {
+	best := 0
+	winners := new(vector.Vector)
+
+	for i := 0; i < matches.Len(); i++ {
+		m := matches.At(i).(*PatternMatch)
+		v, ok := m.valueAt(gap)
+		if !ok || v < best {
+			continue
+		}
+		if v > best {
+			best = v
+			winners.Cut(0, winners.Len())
+		}
+		winners.Push(m)
+	}
+
+	return best, winners
+}
+
+// Lists every pattern which matches the given word, along with the pattern which won at each position
+// and the hyphenation points which result.  Where several patterns supply the same winning value, the
+// first (leftmost) of them is reported.
+func (p *Trie) Explain(word string, leftMin, rightMin int) *Explanation {
+	e := &Explanation{Word: word, Values: new(vector.IntVector), Winners: new(vector.Vector)}
+	e.Matches = p.matchPatterns(`.` + word + `.`)
+
+	length := utf8.RuneCountInString(word)
+	for i := 0; i <= length; i++ {
+		// account for the leading dot
+		best, winners := winningMatches(e.Matches, i+1)
+		e.Values.Push(best)
+		if winners.Len() == 0 {
+			e.Winners.Push(nil)
+		} else {
+			e.Winners.Push(winners.At(0))
+		}
+	}
+
+	e.Points = new(vector.IntVector)
+	for i := leftMin; i <= length-rightMin; i++ {
+		if i > 0 && i < length && e.Values.At(i)%2 == 1 {
+			e.Points.Push(i)
+		}
+	}
+
+	return e
+}
+
+// Internal function: lays out a line of the trace, with each rune of the dotted word in its own column
+// and each value in the column before the rune it precedes.
+func traceLine(width int) []int {
+	line := make([]int, width)
+	for i := range line {
+		line[i] = ' '
+	}
+	return line
+}
+
+// Internal function: places a value in the given gap column of a trace line.
+func placeValue(line []int, gap, value int) {
+	for i, rune := range strconv.Itoa(value) {
+		if 2*gap+i < len(line) {
+			line[2*gap+i] = rune
+		}
+	}
+}
+
+// Writes a trace of the hyphenation in the style of Liang's thesis: the dotted word, then each matching
+// pattern aligned beneath it, then the combined values and finally the hyphenated word in the manner of
+// TeX's \showhyphens.
+func (e *Explanation) WriteTrace(w io.Writer) os.Error {
+	dotted := []int(`.` + e.Word + `.`)
+	width := 2*len(dotted) + 1
+
+	header := traceLine(width)
+	for i, rune := range dotted {
+		header[2*i+1] = rune
+	}
+
+	buf := new(bytes.Buffer)
+	buf.WriteString(string(header) + "\n")
+
+	for i := 0; i < e.Matches.Len(); i++ {
+		m := e.Matches.At(i).(*PatternMatch)
+		line := traceLine(width)
+		for j := 0; j < m.length; j++ {
+			line[2*(m.Offset+j)+1] = dotted[m.Offset+j]
+		}
+		for gap := m.Offset; gap <= m.Offset+m.length; gap++ {
+			if v, ok := m.valueAt(gap); ok && v != 0 {
+				placeValue(line, gap, v)
+			}
+		}
+		buf.WriteString(string(line) + "  " + m.String() + "\n")
+	}
+
+	result := traceLine(width)
+	copy(result, header)
+	for i := 0; i < e.Values.Len(); i++ {
+		if v := e.Values.At(i); v != 0 {
+			placeValue(result, i+1, v)
+		}
+	}
+	buf.WriteString(string(result) + "\n")
+	buf.WriteString(insertHyphens(e.Word, e.Points, `-`) + "\n")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// Returns the trace produced by WriteTrace().
+func (e *Explanation) String() string {
+	buf := new(bytes.Buffer)
+	e.WriteTrace(buf)
+	return buf.String()
+}
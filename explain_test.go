@@ -0,0 +1,93 @@
+/*
+ * explain_test.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"testing"
+	"strings"
+)
+
+func TestExplain(t *testing.T) {
+	trie := NewTrie()
+	trie.AddPatternString(`hy3ph`)
+	trie.AddPatternString(`he2n`)
+	trie.AddPatternString(`hena4`)
+	trie.AddPatternString(`hen5at`)
+
+	e := trie.Explain(`hyphenation`, DefaultLeftHyphenMin, DefaultRightHyphenMin)
+	if e.Matches.Len() != 4 {
+		t.Fatalf("Expected 4 matching patterns, got %d", e.Matches.Len())
+	}
+
+	m := e.Matches.At(0).(*PatternMatch)
+	if m.String() != `hy3ph` || m.Offset != 1 {
+		t.Errorf("Expected first match 'hy3ph' at offset 1, got '%s' at offset %d", m, m.Offset)
+	}
+
+	// 'hena4' and 'hen5at' both cover the position after 'n'; the higher value wins
+	if e.Values.At(6) != 5 {
+		t.Errorf("Expected a value of 5 after 'hyphen', got %d", e.Values.At(6))
+	}
+	if w, ok := e.Winners.At(6).(*PatternMatch); !ok || w.String() != `hen5at` {
+		t.Errorf("Expected 'hen5at' to win after 'hyphen', got %v", e.Winners.At(6))
+	}
+	if e.Winners.At(0) != nil {
+		t.Errorf("Expected no pattern to cover the start of the word, got %v", e.Winners.At(0))
+	}
+
+	// 'hy3ph' covers the position after 'h' with an implied 0
+	if w, ok := e.Winners.At(1).(*PatternMatch); !ok || w.String() != `hy3ph` || e.Values.At(1) != 0 {
+		t.Errorf("Expected 'hy3ph' to supply 0 after 'h', got %v", e.Winners.At(1))
+	}
+	if e.Points.Len() != 2 || e.Points.At(0) != 2 || e.Points.At(1) != 6 {
+		t.Errorf("Expected hyphenation points [2 6], got %v", *e.Points)
+	}
+
+	trace := e.String()
+	lines := strings.Split(strings.TrimSpace(trace), "\n", -1)
+	if len(lines) != 7 {
+		t.Fatalf("Expected 7 lines of trace, got %d:\n%s", len(lines), trace)
+	}
+	if !strings.HasSuffix(lines[1], `hy3ph`) || !strings.Contains(lines[1], `h y3p h`) {
+		t.Errorf("Unexpected trace line for 'hy3ph': '%s'", lines[1])
+	}
+	if lines[6] != `hy-phen-ation` {
+		t.Errorf("Expected the trace to end with 'hy-phen-ation', got '%s'", lines[6])
+	}
+}
@@ -0,0 +1,100 @@
+/*
+ * export.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"bufio"
+	"container/vector"
+	"io"
+	"json"
+	"os"
+)
+
+// Returns every pattern in the trie in TeX form, with its digits interleaved between its letters, in the
+// order of their letters.  Members without a digit vector, such as those added by AddString(), aren't
+// patterns and are left out; so is the empty string, as a pattern must contain letters.
+func (p *Trie) PatternStrings() *vector.StringVector {
+	patterns := new(vector.StringVector)
+	p.each(new(keyBuffer), func(key string, value interface{}) bool {
+		if v, ok := value.(*vector.IntVector); ok && len(key) != 0 {
+			patterns.Push(formatPattern(key, v))
+		}
+		return true
+	})
+	return patterns
+}
+
+// Internal function: writes each pattern on its own line, between a header and a footer, and with the
+// given strings before and after it.
+func (p *Trie) writePatterns(w io.Writer, header, before, after, footer string) os.Error {
+	b := bufio.NewWriter(w)
+	b.WriteString(header)
+
+	patterns := p.PatternStrings()
+	for i := 0; i < patterns.Len(); i++ {
+		b.WriteString(before + patterns.At(i) + after)
+	}
+
+	b.WriteString(footer)
+	return b.Flush()
+}
+
+// Writes the patterns in the trie as a TeX \patterns{} command, which can be read by AddPatterns().
+func (p *Trie) WriteTeXPatterns(w io.Writer) os.Error {
+	return p.writePatterns(w, "\\patterns{\n", ``, "\n", "}\n")
+}
+
+// Writes the patterns in the trie in the same form as the patterns-en file.
+func (p *Trie) WriteGoPatterns(w io.Writer) os.Error {
+	return p.writePatterns(w, "patterns = {\n", "    `", "`,\n", "}\n")
+}
+
+// Writes the patterns in the trie as a JSON object, of the form {"patterns":["hy3ph",...]}.
+func (p *Trie) WriteJSONPatterns(w io.Writer) os.Error {
+	data, err := json.Marshal(map[string]interface{}{`patterns`: p.PatternStrings().Data()})
+	if err != nil {
+		return err
+	}
+
+	if _, err = w.Write(data); err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
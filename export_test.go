@@ -0,0 +1,110 @@
+/*
+ * export_test.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"testing"
+	"bytes"
+	"container/vector"
+)
+
+func TestExportPatterns(t *testing.T) {
+	trie := NewTrie()
+	trie.AddPatternString(`hy3ph`)
+	trie.AddPatternString(`5emnix`)
+	trie.AddPatternString(`.ach4`)
+
+	buf := new(bytes.Buffer)
+	if err := trie.WriteTeXPatterns(buf); err != nil {
+		t.Fatalf("Failed to write TeX patterns: %s", err)
+	}
+	if s := buf.String(); s != "\\patterns{\n.ach4\n5emnix\nhy3ph\n}\n" {
+		t.Errorf("Unexpected TeX patterns:\n%s", s)
+	}
+
+	// the TeX form should read back in unchanged
+	loaded := NewTrie()
+	if _, err := loaded.AddPatterns(buf, Strict); err != nil {
+		t.Fatalf("Failed to read back TeX patterns: %s", err)
+	}
+	diff := DiffPatterns(trie, loaded)
+	if diff.Added.Len() != 0 || diff.Removed.Len() != 0 || diff.Changed.Len() != 0 {
+		t.Errorf("Patterns changed in a round trip: %v %v %v", *diff.Added, *diff.Removed, *diff.Changed)
+	}
+
+	buf.Reset()
+	trie.WriteGoPatterns(buf)
+	if s := buf.String(); s != "patterns = {\n    `.ach4`,\n    `5emnix`,\n    `hy3ph`,\n}\n" {
+		t.Errorf("Unexpected Go patterns:\n%s", s)
+	}
+
+	buf.Reset()
+	trie.WriteJSONPatterns(buf)
+	if s := buf.String(); s != "{\"patterns\":[\".ach4\",\"5emnix\",\"hy3ph\"]}\n" {
+		t.Errorf("Unexpected JSON patterns:\n%s", s)
+	}
+}
+
+func TestExportSkipsNonPatterns(t *testing.T) {
+	trie := NewTrie()
+	trie.AddPatternString(`hy3ph`)
+	trie.AddString(`hyphen`)
+	trie.AddValue(`hen`, `not a digit vector`)
+	trie.AddValue(``, &vector.IntVector{1})
+
+	buf := new(bytes.Buffer)
+	if err := trie.WriteTeXPatterns(buf); err != nil {
+		t.Fatalf("Failed to write TeX patterns: %s", err)
+	}
+	if s := buf.String(); s != "\\patterns{\nhy3ph\n}\n" {
+		t.Errorf("Expected only 'hy3ph' to be written, got:\n%s", s)
+	}
+
+	buf.Reset()
+	trie.WriteGoPatterns(buf)
+	if s := buf.String(); s != "patterns = {\n    `hy3ph`,\n}\n" {
+		t.Errorf("Expected only 'hy3ph' to be written, got:\n%s", s)
+	}
+
+	buf.Reset()
+	trie.WriteJSONPatterns(buf)
+	if s := buf.String(); s != "{\"patterns\":[\"hy3ph\"]}\n" {
+		t.Errorf("Expected only 'hy3ph' to be written, got:\n%s", s)
+	}
+}
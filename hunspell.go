@@ -0,0 +1,233 @@
+/*
+ * hunspell.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"bufio"
+	"container/vector"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// A HyphDictionary holds the contents of a LibreOffice/Hunspell hyphenation dictionary, such as
+// hyph_en_US.dic.
+type HyphDictionary struct {
+	Charset                string               // the character set named on the first line.
+	LeftHyphenMin          int                  // the LEFTHYPHENMIN directive, or zero if absent.
+	RightHyphenMin         int                  // the RIGHTHYPHENMIN directive, or zero if absent.
+	CompoundLeftHyphenMin  int                  // the COMPOUNDLEFTHYPHENMIN directive, or zero if absent.
+	CompoundRightHyphenMin int                  // the COMPOUNDRIGHTHYPHENMIN directive, or zero if absent.
+	NoHyphen               *vector.StringVector // the strings listed by the NOHYPHEN directive.
+
+	// The pattern tries, one for each level of the dictionary.  In a two-level dictionary the first
+	// level marks compound boundaries, and the second hyphenates within each component.
+	Levels *vector.Vector
+}
+
+// Upper halves of the single-byte character sets supported by LoadHyphDictionary(), where they differ
+// from ISO 8859-1.
+var charsetExceptions = map[string]map[byte]int{
+	`ISO8859-1`: map[byte]int{},
+	`ISO8859-15`: map[byte]int{
+		0xA4: '€', 0xA6: 'Š', 0xA8: 'š', 0xB4: 'Ž', 0xB8: 'ž', 0xBC: 'Œ', 0xBD: 'œ', 0xBE: 'Ÿ',
+	},
+}
+
+// Internal function: converts a line in the given single-byte character set to UTF-8.
+func decodeLine(line string, exceptions map[byte]int) string {
+	runes := make([]int, len(line))
+	for i := 0; i < len(line); i++ {
+		if rune, ok := exceptions[line[i]]; ok {
+			runes[i] = rune
+		} else {
+			runes[i] = int(line[i])
+		}
+	}
+	return string(runes)
+}
+
+// Internal function: reads the numeric argument of a directive.
+func directiveValue(fields []string, line int) (int, os.Error) {
+	if len(fields) < 2 {
+		return 0, os.NewError(`line ` + strconv.Itoa(line) + `: missing value for ` + fields[0])
+	}
+	n, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return 0, os.NewError(`line ` + strconv.Itoa(line) + `: invalid value for ` + fields[0])
+	}
+	return n, nil
+}
+
+// Reads a LibreOffice/Hunspell hyphenation dictionary.  The first line names the character set, which
+// may be UTF-8, ISO8859-1 or ISO8859-15; the directives LEFTHYPHENMIN, RIGHTHYPHENMIN,
+// COMPOUNDLEFTHYPHENMIN, COMPOUNDRIGHTHYPHENMIN, NOHYPHEN and NEXTLEVEL are recognized, and every other
+// line holds a pattern.  Hyphens and apostrophes are accepted as letters in patterns, as in the compound
+// patterns '1-1' and '1'1' used by many dictionaries.  Patterns are checked using the given mode, as by
+// AddPatterns(), and any warnings are returned as elements of type *PatternError.  Non-standard
+// hyphenation, such as 'c1k/k=k,1,1', is not supported: the pattern is used without its replacement, and
+// a warning is given.
+func LoadHyphDictionary(r io.Reader, mode ParseMode) (*HyphDictionary, *vector.Vector, os.Error) {
+	d := &HyphDictionary{NoHyphen: new(vector.StringVector), Levels: new(vector.Vector)}
+	d.Levels.Push(NewTrie())
+	warnings := new(vector.Vector)
+	reader := bufio.NewReader(r)
+
+	mins := map[string]*int{
+		`LEFTHYPHENMIN`:          &d.LeftHyphenMin,
+		`RIGHTHYPHENMIN`:         &d.RightHyphenMin,
+		`COMPOUNDLEFTHYPHENMIN`:  &d.CompoundLeftHyphenMin,
+		`COMPOUNDRIGHTHYPHENMIN`: &d.CompoundRightHyphenMin,
+	}
+
+	var exceptions map[byte]int
+	for line := 1; ; line++ {
+		s, err := reader.ReadString('\n')
+		if err != nil && err != os.EOF {
+			return nil, warnings, err
+		}
+		if exceptions != nil {
+			s = decodeLine(s, exceptions)
+		}
+		if line == 1 && strings.HasPrefix(s, "\ufeff") {
+			// skip the byte order mark
+			s = s[len("\ufeff"):]
+		}
+
+		fields := strings.Fields(s)
+		switch {
+		case len(fields) == 0 || fields[0][0] == '%' || fields[0][0] == '#':
+			// blank or comment
+		case len(d.Charset) == 0:
+			d.Charset = fields[0]
+			if d.Charset != `UTF-8` {
+				var ok bool
+				if exceptions, ok = charsetExceptions[d.Charset]; !ok {
+					return nil, warnings, os.NewError(`unsupported character set ` + d.Charset)
+				}
+			}
+		case mins[fields[0]] != nil:
+			n, derr := directiveValue(fields, line)
+			if derr != nil {
+				return nil, warnings, derr
+			}
+			*mins[fields[0]] = n
+		case fields[0] == `NOHYPHEN`:
+			if len(fields) > 1 {
+				for _, nohyphen := range strings.Split(fields[1], `,`, -1) {
+					d.NoHyphen.Push(nohyphen)
+				}
+			}
+		case fields[0] == `NEXTLEVEL`:
+			d.Levels.Push(NewTrie())
+		default:
+			pattern := fields[0]
+			if i := strings.Index(pattern, `/`); i >= 0 {
+				warnings.Push(&PatternError{pattern, len([]int(pattern[0:i])), line, NonStandardPattern})
+				pattern = pattern[0:i]
+			}
+
+			level := d.Levels.Last().(*Trie)
+			w, perr := level.addCheckedPattern(pattern, mode, isHyphPatternLetter)
+			for i := 0; i < w.Len(); i++ {
+				w.At(i).(*PatternError).Line = line
+			}
+			warnings.AppendVector(w)
+			if perr != nil {
+				e, ok := perr.(*PatternError)
+				if ok {
+					e.Line = line
+				}
+				if mode == Strict || !ok {
+					return nil, warnings, perr
+				}
+				warnings.Push(e)
+			}
+		}
+
+		if err == os.EOF {
+			break
+		}
+	}
+
+	return d, warnings, nil
+}
+
+// The compound patterns which libhyphen uses in place of a first level when a dictionary has only one.
+var defaultCompoundPatterns = []string{`1-1`, `1'1`, `1–1`, `1’1`}
+
+// Creates and returns a new Hyphenator using the dictionary's patterns and directives.  In a two-level
+// dictionary the first level supplies the compound patterns; otherwise, as in libhyphen, the word is
+// split at hyphens, dashes and apostrophes.  Apostrophes are looked up as letters, so that the compound
+// patterns can find boundaries beside them, as libhyphen does.  As in libhyphen, LEFTHYPHENMIN and
+// RIGHTHYPHENMIN also limit the compound boundaries found by the first level, while the COMPOUND
+// directives limit hyphens beside those boundaries.  Directives missing from the dictionary leave the
+// Hyphenator's defaults in place.
+func (d *HyphDictionary) NewHyphenator() *Hyphenator {
+	h := NewHyphenator(d.Levels.Last().(*Trie))
+	h.Apostrophes = ApostropheLetter
+	if d.Levels.Len() > 1 {
+		h.CompoundPatterns = d.Levels.At(0).(*Trie)
+	} else {
+		h.CompoundPatterns = NewTrie()
+		for _, pattern := range defaultCompoundPatterns {
+			h.CompoundPatterns.addCheckedPattern(pattern, Strict, isHyphPatternLetter)
+		}
+	}
+
+	if d.LeftHyphenMin != 0 {
+		h.LeftMin = d.LeftHyphenMin
+		h.BoundaryLeftMin = d.LeftHyphenMin
+	}
+	if d.RightHyphenMin != 0 {
+		h.RightMin = d.RightHyphenMin
+		h.BoundaryRightMin = d.RightHyphenMin
+	}
+	if d.CompoundLeftHyphenMin != 0 {
+		h.CompoundLeftMin = d.CompoundLeftHyphenMin
+	}
+	if d.CompoundRightHyphenMin != 0 {
+		h.CompoundRightMin = d.CompoundRightHyphenMin
+	}
+	if d.NoHyphen.Len() != 0 {
+		h.NoHyphen = d.NoHyphen
+	}
+	return h
+}
@@ -0,0 +1,166 @@
+/*
+ * hunspell_test.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"testing"
+	"strings"
+)
+
+func TestLoadHyphDictionary(t *testing.T) {
+	dic := "ISO8859-1\n" +
+		"LEFTHYPHENMIN 2\n" +
+		"RIGHTHYPHENMIN 2\n" +
+		"COMPOUNDLEFTHYPHENMIN 2\n" +
+		"COMPOUNDRIGHTHYPHENMIN 3\n" +
+		"NOHYPHEN ',-\n" +
+		"% the compound level\n" +
+		"s1m\n" +
+		"NEXTLEVEL\n" +
+		"m1m\n" +
+		"\xe41t\n" +
+		"c1k/k=k,1,1\n"
+
+	d, warnings, err := LoadHyphDictionary(strings.NewReader(dic), Strict)
+	if err != nil {
+		t.Fatalf("Failed to load dictionary: %s", err)
+	}
+	if d.Charset != `ISO8859-1` || d.RightHyphenMin != 2 || d.CompoundRightHyphenMin != 3 {
+		t.Errorf("Directives weren't read correctly: %v", *d)
+	}
+	if d.NoHyphen.Len() != 2 || d.NoHyphen.At(0) != `'` || d.NoHyphen.At(1) != `-` {
+		t.Errorf("Expected NOHYPHEN strings [' -], got %v", *d.NoHyphen)
+	}
+	if d.Levels.Len() != 2 {
+		t.Fatalf("Expected 2 levels, got %d", d.Levels.Len())
+	}
+	if !d.Levels.At(1).(*Trie).Contains(`ät`) {
+		t.Error("The second level should contain the ISO8859-1 pattern 'ä1t'")
+	}
+	if warnings.Len() != 1 || warnings.At(0).(*PatternError).Kind != NonStandardPattern {
+		t.Errorf("Expected a warning for the non-standard pattern, got %v", *warnings)
+	}
+
+	h := d.NewHyphenator()
+	words := map[string]string{
+		`hausmammut`: `haus-mam-mut`,
+		`rätsel`:     `rä-tsel`,
+		`mausmo`:     `maus-mo`,
+		`e-mail`:     `e-mail`,
+	}
+	for word, expected := range words {
+		if s := h.Hyphenate(word, `-`); s != expected {
+			t.Errorf("Expected '%s' to hyphenate as '%s', got '%s'", word, expected, s)
+		}
+	}
+	if breaks := h.Breaks(`e-mail`); breaks.Len() != 0 {
+		t.Errorf("NOHYPHEN should prevent a break after the hyphen in 'e-mail', got %v", *breaks)
+	}
+
+	// a stray digit is skipped leniently, but stops a strict load
+	d, warnings, err = LoadHyphDictionary(strings.NewReader("UTF-8\nm1m\n4\nn1n\n"), Lenient)
+	if err != nil || warnings.Len() != 1 || !d.Levels.At(0).(*Trie).Contains(`nn`) {
+		t.Errorf("Expected the stray digit to be skipped with a warning, got %v", err)
+	}
+	if _, _, err = LoadHyphDictionary(strings.NewReader("UTF-8\nm1m\n4\nn1n\n"), Strict); err == nil {
+		t.Error("Expected an error for a stray digit in strict mode")
+	}
+
+	_, _, err = LoadHyphDictionary(strings.NewReader("KOI8-R\nm1m\n"), Strict)
+	if err == nil {
+		t.Error("Expected an error for an unsupported character set")
+	}
+}
+
+func TestLoadHyphDictionaryPunctuation(t *testing.T) {
+	dic := "UTF-8\n" +
+		"LEFTHYPHENMIN 2\n" +
+		"RIGHTHYPHENMIN 2\n" +
+		"1-1\n" +
+		"1'1\n" +
+		"1’1\n" +
+		"NEXTLEVEL\n" +
+		"m1m\n"
+
+	for _, mode := range []ParseMode{Strict, Lenient} {
+		d, warnings, err := LoadHyphDictionary(strings.NewReader(dic), mode)
+		if err != nil {
+			t.Fatalf("Failed to load dictionary with hyphen and apostrophe patterns: %s", err)
+		}
+		if warnings.Len() != 0 {
+			t.Errorf("Expected no warnings for hyphen and apostrophe patterns, got %v", *warnings)
+		}
+
+		compound := d.Levels.At(0).(*Trie)
+		for _, pattern := range []string{`-`, `'`, `’`} {
+			if !compound.Contains(pattern) {
+				t.Errorf("The first level should contain the pattern '1%s1'", pattern)
+			}
+		}
+	}
+
+	// outside a dictionary, hyphens are still rejected
+	if _, _, _, err := ParsePattern(`1-1`, Strict); err == nil {
+		t.Error("Expected ParsePattern() to reject '1-1'")
+	}
+}
+
+func TestHyphDictionaryApostrophes(t *testing.T) {
+	dic := "UTF-8\n" +
+		"LEFTHYPHENMIN 2\n" +
+		"RIGHTHYPHENMIN 2\n" +
+		"m1m\n"
+
+	d, _, err := LoadHyphDictionary(strings.NewReader(dic), Strict)
+	if err != nil {
+		t.Fatalf("Failed to load dictionary: %s", err)
+	}
+
+	// as in libhyphen, a single-level dictionary allows a break after an apostrophe
+	h := d.NewHyphenator()
+	words := map[string]string{
+		`l'homme`: `l'-hom-me`,
+		`l’homme`: `l’-hom-me`,
+	}
+	for word, expected := range words {
+		if s := h.Hyphenate(word, `-`); s != expected {
+			t.Errorf("Expected '%s' to hyphenate as '%s', got '%s'", word, expected, s)
+		}
+	}
+}
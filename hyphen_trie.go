@@ -39,63 +39,161 @@
 package trie
 
 import (
-	"unicode"
 	"utf8"
 	"container/vector"
-	"strings"
+	"strconv"
+	"bytes"
 )
 
-
 // Specialized function for TeX-style hyphenation patterns.  Accepts strings of the form '.hy2p'.
-// The value it stores is of type vector.IntVector
+// The value it stores is of type vector.IntVector.  Malformed patterns are repaired where possible
+// and otherwise ignored; use AddCheckedPatternString() to find out about them.
 func (p *Trie) AddPatternString(s string) {
-	v := new(vector.IntVector)
+	p.AddCheckedPatternString(s, Lenient)
+}
 
-	// precompute the Unicode rune for the character '0'
-	rune0, _ := utf8.DecodeRune([]byte{'0'})
+// The minimum number of characters TeX leaves before and after a hyphen, by default.
+const (
+	DefaultLeftHyphenMin  = 2
+	DefaultRightHyphenMin = 3
+)
 
-	strLen := len(s)
+// A PatternMatch records a single hyphenation pattern found within a dotted word (e.g. '.hyphenation.').
+type PatternMatch struct {
+	Letters string            // the letters of the pattern, without any digits.
+	Offset  int               // the rune offset of the pattern's first letter within the dotted word.
+	Values  *vector.IntVector // the digit vector stored with the pattern.
+	length  int               // the number of runes in the pattern's letters.
+}
 
-	// Using the range keyword will give us each Unicode rune.
-	for pos, rune := range s {
-		if unicode.IsDigit(rune) {
-			if pos == 0 {
-				// This is a prefix number
-				v.Push(rune - rune0)
-			}
+// Returns the value this pattern assigns to the given gap of the dotted word, where gap i is the position
+// immediately before rune i.  The boolean result is false if the pattern doesn't cover that gap.
+func (m *PatternMatch) valueAt(gap int) (int, bool) {
+	// a vector with one more element than there are letters begins with a prefix value
+	first := m.Offset + m.length - m.Values.Len() + 1
+	i := gap - first
+	if i < 0 || i >= m.Values.Len() {
+		return 0, false
+	}
+	return m.Values.At(i), true
+}
 
-			// this is a number referring to the previous character, and has
-			// already been handled
-			continue
+// Returns the pattern in TeX form, with its digits interleaved between the letters.
+func (m *PatternMatch) String() string {
+	return formatPattern(m.Letters, m.Values)
+}
+
+// Internal function: re-interleaves a digit vector with the letters it was stored against.  Zero values
+// are implied, and are therefore omitted; so are any values missing from the start of a short vector.
+func formatPattern(letters string, values *vector.IntVector) string {
+	buf := new(bytes.Buffer)
+	i := values.Len() - utf8.RuneCountInString(letters)
+	if i > 0 && values.At(0) != 0 {
+		buf.WriteString(strconv.Itoa(values.At(0)))
+	}
+
+	for _, rune := range letters {
+		buf.WriteString(string(rune))
+		if i >= 0 && i < values.Len() && values.At(i) != 0 {
+			buf.WriteString(strconv.Itoa(values.At(i)))
+		}
+		i++
+	}
+
+	return buf.String()
+}
+
+// Internal function: finds every pattern in the trie which matches some part of the dotted word.  The
+// elements of the returned vector are of type *PatternMatch, in order of their offsets.
+func (p *Trie) matchPatterns(dotted string) *vector.Vector {
+	matches := new(vector.Vector)
+
+	offset := 0
+	for pos, _ := range dotted {
+		strs, values := p.AllSubstringsAndValues(dotted[pos:])
+		for i := 0; i < strs.Len(); i++ {
+			v, ok := values.At(i).(*vector.IntVector)
+			if !ok {
+				// not a pattern string
+				continue
+			}
+			str := strs.At(i)
+			matches.Push(&PatternMatch{str, offset, v, utf8.RuneCountInString(str)})
 		}
+		offset++
+	}
+
+	return matches
+}
 
-		if pos < strLen-1 {
-			// look ahead to see if it's followed by a number
-			next := int(s[pos+1])
-			if unicode.IsDigit(next) {
-				// next char is the hyphenation value for this char
-				v.Push(next - rune0)
-			} else {
-				// hyphenation for this char is an implied zero
-				v.Push(0)
+// Internal function: computes the value of each gap in a dotted word of the given length from a set of
+// pattern matches, taking the largest value from any pattern at each position.
+func gapValues(matches *vector.Vector, length int) []int {
+	gaps := make([]int, length+1)
+	for i := 0; i < matches.Len(); i++ {
+		m := matches.At(i).(*PatternMatch)
+		for gap := 0; gap < len(gaps); gap++ {
+			if v, ok := m.valueAt(gap); ok && v > gaps[gap] {
+				gaps[gap] = v
 			}
-		} else {
-			// last character gets an implied zero
-			v.Push(0)
 		}
 	}
+	return gaps
+}
+
+// Computes the hyphenation values of a word from the patterns stored in the trie.  The returned vector
+// has one more element than there are runes in the word: element i holds the value of the position
+// immediately before the i'th rune.  Odd values denote permitted hyphenation points.
+func (p *Trie) HyphenationValues(word string) *vector.IntVector {
+	dotted := `.` + word + `.`
+	gaps := gapValues(p.matchPatterns(dotted), utf8.RuneCountInString(dotted))
+
+	// strip the positions around the enclosing dots
+	v := new(vector.IntVector)
+	for _, value := range gaps[1 : len(gaps)-1] {
+		v.Push(value)
+	}
+	return v
+}
+
+// Returns the rune offsets within a word at which it may be hyphenated.  No hyphen will be placed
+// with fewer than leftMin runes before it or fewer than rightMin runes after it.
+func (p *Trie) HyphenationPoints(word string, leftMin, rightMin int) *vector.IntVector {
+	points := new(vector.IntVector)
+	values := p.HyphenationValues(word)
+	length := values.Len() - 1
+
+	for i := 1; i < length; i++ {
+		if i < leftMin || length-i < rightMin {
+			continue
+		}
+		if values.At(i)%2 == 1 {
+			points.Push(i)
+		}
+	}
+
+	return points
+}
+
+// Returns the word with the given hyphen string inserted at each of its hyphenation points.
+func (p *Trie) Hyphenate(word, hyphen string, leftMin, rightMin int) string {
+	return insertHyphens(word, p.HyphenationPoints(word, leftMin, rightMin), hyphen)
+}
+
+// Internal function: inserts a hyphen string before each of the given rune offsets, which must be sorted.
+func insertHyphens(word string, points *vector.IntVector, hyphen string) string {
+	buf := new(bytes.Buffer)
+	next := 0
 
-	pure := strings.Map(func(rune int) int {
-		if unicode.IsDigit(rune) {
-			return -1
+	i := 0
+	for _, rune := range word {
+		if next < points.Len() && points.At(next) == i {
+			buf.WriteString(hyphen)
+			next++
 		}
-		return rune
-	},
-		s)
-	leaf := p.addRunes(strings.NewReader(pure))
-	if leaf == nil {
-		return
+		buf.WriteString(string(rune))
+		i++
 	}
 
-	leaf.value = v
+	return buf.String()
 }
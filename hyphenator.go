@@ -0,0 +1,407 @@
+/*
+ * hyphenator.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"container/vector"
+	"unicode"
+)
+
+// BreakPenalties determine the penalty given to each possible break in a word, for use by a line
+// breaker such as Knuth and Plass's.  Lower penalties denote better breaks; they may become negative.
+type BreakPenalties struct {
+	Hyphen       int // the penalty for a hyphen found by the patterns, like TeX's \hyphenpenalty.
+	Compound     int // the penalty for a break at a compound boundary.
+	Explicit     int // the penalty for a break after a hyphen already in the word, like \exhyphenpenalty.
+	Strength     int // deducted for each step of the pattern value above 1, i.e. once for 3, twice for 5.
+	Edge         int // added for each rune by which a hyphen falls short of EdgeDistance.
+	EdgeDistance int // the distance from a word edge or compound boundary at which hyphens become ugly.
+}
+
+// The default penalties.
+var DefaultBreakPenalties = BreakPenalties{Hyphen: 50, Compound: 20, Explicit: 50, Strength: 10, Edge: 25,
+	EdgeDistance: 3}
+
+// Returns the penalty for a hyphen with the given pattern value, at the given distance from the nearest
+// word edge or compound boundary.
+func (b *BreakPenalties) hyphenPenalty(value, distance int) int {
+	penalty := b.Hyphen - b.Strength*((value-1)/2)
+	if distance < b.EdgeDistance {
+		penalty += b.Edge * (b.EdgeDistance - distance)
+	}
+	return penalty
+}
+
+// A Break describes a position at which a word may be hyphenated.
+type Break struct {
+	Offset   int  // the rune offset of the break within the word.
+	Value    int  // the pattern value at the break, or zero at a compound boundary.
+	Compound bool // whether the break is at a boundary between compound components.
+	Explicit bool // whether the break follows a hyphen already in the word, so needs no hyphen adding.
+	Penalty  int  // the cost of breaking here.
+}
+
+// An ApostropheRule determines how a Hyphenator treats apostrophes within words.
+type ApostropheRule int
+
+const (
+	ApostropheIgnore ApostropheRule = iota // removed for lookup, with no break beside them, as in English.
+	ApostropheSplit                        // the parts either side are hyphenated separately, as in French.
+	ApostropheLetter                       // looked up as a letter, for patterns which contain apostrophes.
+)
+
+// A Hyphenator combines a pattern trie with the settings used to hyphenate words with it.  Compounds are
+// split using the lexicon where possible, and otherwise at the odd values given by the compound patterns,
+// which form the first level of a two-level Hunspell dictionary.
+type Hyphenator struct {
+	Patterns         *Trie                // the hyphenation patterns.
+	Lexicon          *Trie                // if not nil, words used to split compounds, as by SegmentCompound().
+	MinComponent     int                  // the shortest compound component, in runes.
+	LeftMin          int                  // the fewest runes before a hyphen.
+	RightMin         int                  // the fewest runes after a hyphen.
+	CompoundPatterns *Trie                // if not nil, patterns marking compound boundaries where the lexicon doesn't.
+	BoundaryLeftMin  int                  // the fewest runes before a boundary found by the compound patterns.
+	BoundaryRightMin int                  // the fewest runes after a boundary found by the compound patterns.
+	CompoundLeftMin  int                  // the fewest runes after a compound boundary before a hyphen.
+	CompoundRightMin int                  // the fewest runes before a compound boundary after a hyphen.
+	NoHyphen         *vector.StringVector // if not nil, strings beside which a word is never broken.
+	Penalties        BreakPenalties       // the penalties given to each break.
+	Cache            *BreakCache          // if not nil, remembers the breaks found in recently hyphenated words.
+	Normalize        bool                 // whether words are normalized, as by NormalizeWord(), before lookup.
+	Apostrophes      ApostropheRule       // how apostrophes within words are treated.
+}
+
+// Creates and returns a new Hyphenator using the given patterns and the default settings.
+func NewHyphenator(patterns *Trie) *Hyphenator {
+	h := new(Hyphenator)
+	h.Patterns = patterns
+	h.MinComponent = 3
+	h.LeftMin = DefaultLeftHyphenMin
+	h.RightMin = DefaultRightHyphenMin
+	h.BoundaryLeftMin = DefaultLeftHyphenMin
+	h.BoundaryRightMin = DefaultRightHyphenMin
+	h.CompoundLeftMin = DefaultLeftHyphenMin
+	h.CompoundRightMin = DefaultRightHyphenMin
+	h.Penalties = DefaultBreakPenalties
+	h.Normalize = true
+	return h
+}
+
+// Internal function: splits a word into its compound components, using the lexicon or the compound
+// patterns.
+func (h *Hyphenator) components(word string) *vector.StringVector {
+	var components *vector.StringVector
+	if h.Lexicon != nil {
+		components = h.Lexicon.SegmentCompound(word, h.MinComponent)
+	}
+	if components == nil && h.CompoundPatterns != nil {
+		points := h.CompoundPatterns.HyphenationPoints(word, h.BoundaryLeftMin, h.BoundaryRightMin)
+		if points.Len() != 0 {
+			components = splitWord(word, points)
+		}
+	}
+	if components == nil {
+		components = new(vector.StringVector)
+		components.Push(word)
+	}
+	return components
+}
+
+// Internal function: splits a word before each of the given rune offsets, which must be sorted.
+func splitWord(word string, points *vector.IntVector) *vector.StringVector {
+	parts := new(vector.StringVector)
+	runes := []int(word)
+
+	start := 0
+	for i := 0; i < points.Len(); i++ {
+		parts.Push(string(runes[start:points.At(i)]))
+		start = points.At(i)
+	}
+	parts.Push(string(runes[start:]))
+	return parts
+}
+
+// Internal function: whether a rune is an apostrophe.
+func isApostrophe(rune int) bool {
+	return rune == '\'' || rune == '’'
+}
+
+// Internal function: whether a rune is a hyphen at which a word may already be broken.  Non-breaking
+// hyphens are not included.
+func isHyphen(rune int) bool {
+	return rune == '-' || rune == '‐'
+}
+
+// Internal function: whether a rune can begin or end a word, as opposed to surrounding punctuation.
+func isWordRune(rune int) bool {
+	return unicode.IsLetter(rune) || unicode.Is(unicode.Mn, rune)
+}
+
+// Internal function: adds each break to a vector after moving it to the offset given by f, discarding
+// those for which f returns false.
+func mapBreaks(breaks, into *vector.Vector, f func(int) (int, bool)) {
+	for i := 0; i < breaks.Len(); i++ {
+		b := breaks.At(i).(*Break)
+		if offset, ok := f(b.Offset); ok {
+			b.Offset = offset
+			into.Push(b)
+		}
+	}
+}
+
+// Internal function: returns a function which moves an offset within a rewritten word, such as a
+// normalized one, to the offset within the original word, given the origin of each rewritten rune.  An
+// offset is only moved if every rune before it comes from an earlier rune of the original than every
+// rune after it, so none falls within an expanded ligature or among reordered combining marks, and the
+// moved offsets stay in order.
+func originOffsets(origins []int) func(int) (int, bool) {
+	// the latest origin up to each rune, and the earliest from each rune onwards
+	latest := make([]int, len(origins))
+	earliest := make([]int, len(origins))
+	for i := range origins {
+		latest[i] = origins[i]
+		if i > 0 {
+			latest[i] = max(latest[i-1], origins[i])
+		}
+	}
+	for i := len(origins) - 1; i >= 0; i-- {
+		earliest[i] = origins[i]
+		if i < len(origins)-1 {
+			earliest[i] = min(earliest[i+1], origins[i])
+		}
+	}
+
+	return func(offset int) (int, bool) {
+		if offset <= 0 || offset >= len(origins) || latest[offset-1] >= earliest[offset] {
+			return 0, false
+		}
+		return earliest[offset], true
+	}
+}
+
+// Returns the positions at which a word may be broken, with their penalties.  Compound boundaries are
+// always breaks, and within each component the patterns supply the rest.  The elements of the returned
+// vector are of type *Break, in order of offset.
+//
+// Offsets always refer to the runes of the word as given.  Punctuation around the word is ignored, and
+// words containing digits are never broken.  A word containing hyphens may be broken after each of them,
+// with each part hyphenated separately, and apostrophes are treated according to the Apostrophes rule.
+func (h *Hyphenator) Breaks(word string) *vector.Vector {
+	breaks := new(vector.Vector)
+	runes := []int(word)
+
+	// digits anywhere in the token, even beyond its letters, rule out any break
+	for _, rune := range runes {
+		if unicode.IsDigit(rune) {
+			return breaks
+		}
+	}
+
+	// trim any surrounding quotes, brackets or punctuation
+	start, end := 0, len(runes)
+	for start < end && !isWordRune(runes[start]) {
+		start++
+	}
+	for end > start && !isWordRune(runes[end-1]) {
+		end--
+	}
+
+	part := start
+	for i := start; i <= end; i++ {
+		if i < end && !isHyphen(runes[i]) && !(h.Apostrophes == ApostropheSplit && isApostrophe(runes[i])) {
+			continue
+		}
+
+		if i > part {
+			mapBreaks(h.partBreaks(runes[part:i]), breaks, func(offset int) (int, bool) {
+				return part + offset, true
+			})
+		}
+		if i < end && isHyphen(runes[i]) && i > start && i+1 < end {
+			breaks.Push(&Break{i + 1, 0, false, true, h.Penalties.Explicit})
+		}
+		part = i + 1
+	}
+
+	if h.NoHyphen != nil {
+		breaks = h.removeNoHyphenBreaks(runes, breaks)
+	}
+	return breaks
+}
+
+// Internal function: discards any breaks immediately before or after one of the NoHyphen strings.
+func (h *Hyphenator) removeNoHyphenBreaks(runes []int, breaks *vector.Vector) *vector.Vector {
+	banned := make(map[int]bool)
+	for i := 0; i < h.NoHyphen.Len(); i++ {
+		s := []int(h.NoHyphen.At(i))
+		if len(s) == 0 {
+			continue
+		}
+
+		for start := 0; start+len(s) <= len(runes); start++ {
+			if string(runes[start:start+len(s)]) == string(s) {
+				banned[start] = true
+				banned[start+len(s)] = true
+			}
+		}
+	}
+
+	allowed := new(vector.Vector)
+	mapBreaks(breaks, allowed, func(offset int) (int, bool) {
+		return offset, !banned[offset]
+	})
+	return allowed
+}
+
+// Internal function: returns the breaks in part of a word lying between hyphens, removing any
+// apostrophes first if they are to be ignored.
+func (h *Hyphenator) partBreaks(runes []int) *vector.Vector {
+	if h.Apostrophes != ApostropheIgnore {
+		return h.normalizedBreaks(string(runes))
+	}
+
+	letters := new(vector.IntVector)
+	origins := new(vector.IntVector)
+	for i, rune := range runes {
+		if !isApostrophe(rune) {
+			letters.Push(rune)
+			origins.Push(i)
+		}
+	}
+	if letters.Len() == 0 {
+		return new(vector.Vector)
+	}
+
+	// the origins only increase, so the breaks stay in order
+	breaks := new(vector.Vector)
+	mapBreaks(h.normalizedBreaks(string(letters.Data())), breaks, func(offset int) (int, bool) {
+		if offset <= 0 || offset >= origins.Len() {
+			return 0, false
+		}
+		// there's no break beside an apostrophe
+		return origins.At(offset), origins.At(offset)-origins.At(offset-1) == 1
+	})
+	return breaks
+}
+
+// Internal function: returns the breaks in a word, normalizing it first if required.  No break is
+// reported within an expanded ligature, or among combining marks which normalization reordered.
+func (h *Hyphenator) normalizedBreaks(word string) *vector.Vector {
+	if !h.Normalize {
+		return h.cachedBreaks(word)
+	}
+
+	normalized, origins := normalizeWord(word)
+	breaks := new(vector.Vector)
+	mapBreaks(h.cachedBreaks(normalized), breaks, originOffsets(origins))
+	return breaks
+}
+
+// Internal function: returns the breaks in a word from the cache, finding and caching them if necessary.
+func (h *Hyphenator) cachedBreaks(word string) *vector.Vector {
+	if h.Cache == nil {
+		return h.findBreaks(word)
+	}
+
+	if breaks, ok := h.Cache.get(word); ok {
+		return breaks
+	}
+	breaks := h.findBreaks(word)
+	h.Cache.put(word, breaks)
+	return breaks
+}
+
+// Internal function: finds the breaks in a word, without consulting the cache.
+func (h *Hyphenator) findBreaks(word string) *vector.Vector {
+	breaks := new(vector.Vector)
+	components := h.components(word)
+
+	offset := 0
+	for i := 0; i < components.Len(); i++ {
+		if i != 0 {
+			breaks.Push(&Break{offset, 0, true, false, h.Penalties.Compound})
+		}
+
+		// the compound minimums apply beside compound boundaries
+		leftMin, rightMin := h.LeftMin, h.RightMin
+		if i != 0 {
+			leftMin = h.CompoundLeftMin
+		}
+		if i != components.Len()-1 {
+			rightMin = h.CompoundRightMin
+		}
+
+		values := h.Patterns.HyphenationValues(components.At(i))
+		length := values.Len() - 1
+		for j := 1; j < length; j++ {
+			value := values.At(j)
+			if j < leftMin || length-j < rightMin || value%2 == 0 {
+				continue
+			}
+			penalty := h.Penalties.hyphenPenalty(value, min(j, length-j))
+			breaks.Push(&Break{offset + j, value, false, false, penalty})
+		}
+		offset += length
+	}
+
+	return breaks
+}
+
+// Returns the rune offsets of each break in a word.
+func (h *Hyphenator) Points(word string) *vector.IntVector {
+	breaks := h.Breaks(word)
+	points := new(vector.IntVector)
+	for i := 0; i < breaks.Len(); i++ {
+		points.Push(breaks.At(i).(*Break).Offset)
+	}
+	return points
+}
+
+// Returns the word with the given hyphen string inserted at each break, other than those following
+// hyphens already in the word.
+func (h *Hyphenator) Hyphenate(word, hyphen string) string {
+	breaks := h.Breaks(word)
+	points := new(vector.IntVector)
+	for i := 0; i < breaks.Len(); i++ {
+		if b := breaks.At(i).(*Break); !b.Explicit {
+			points.Push(b.Offset)
+		}
+	}
+	return insertHyphens(word, points, hyphen)
+}
@@ -0,0 +1,146 @@
+/*
+ * hyphenator_test.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"testing"
+)
+
+func TestBreakPenalties(t *testing.T) {
+	patterns := NewTrie()
+	patterns.AddPatternString(`hy3ph`)
+	patterns.AddPatternString(`hen5at`)
+	patterns.AddPatternString(`s1s`)
+
+	h := NewHyphenator(patterns)
+	breaks := h.Breaks(`hyphenation`)
+	if breaks.Len() != 2 {
+		t.Fatalf("Expected 2 breaks in 'hyphenation', got %d", breaks.Len())
+	}
+
+	// 'hy-' is close to the start of the word, while 'hen5at' is strong and central
+	b := breaks.At(0).(*Break)
+	if b.Offset != 2 || b.Value != 3 || b.Penalty != 65 {
+		t.Errorf("Expected a break at 2 with value 3 and penalty 65, got %v", *b)
+	}
+	b = breaks.At(1).(*Break)
+	if b.Offset != 6 || b.Value != 5 || b.Penalty != 30 {
+		t.Errorf("Expected a break at 6 with value 5 and penalty 30, got %v", *b)
+	}
+
+	h.Lexicon = NewTrie()
+	h.Lexicon.AddString(`haus`)
+	h.Lexicon.AddString(`schlüssel`)
+
+	breaks = h.Breaks(`hausschlüssel`)
+	if breaks.Len() != 2 {
+		t.Fatalf("Expected 2 breaks in 'hausschlüssel', got %d", breaks.Len())
+	}
+	b = breaks.At(0).(*Break)
+	if b.Offset != 4 || !b.Compound || b.Penalty != DefaultBreakPenalties.Compound {
+		t.Errorf("Expected a compound break at 4, got %v", *b)
+	}
+
+	// the edge distance is measured from the compound boundary, not the start of the word
+	b = breaks.At(1).(*Break)
+	if b.Offset != 10 || b.Penalty != 50 {
+		t.Errorf("Expected a break at 10 with penalty 50, got %v", *b)
+	}
+	if s := h.Hyphenate(`hausschlüssel`, `-`); s != `haus-schlüs-sel` {
+		t.Errorf("Expected 'haus-schlüs-sel', got '%s'", s)
+	}
+}
+
+func TestPunctuation(t *testing.T) {
+	patterns := NewTrie()
+	patterns.AddPatternString(`hy3ph`)
+	patterns.AddPatternString(`hen5at`)
+	patterns.AddPatternString(`n1t`)
+	patterns.AddPatternString(`m1m`)
+	patterns.AddPatternString(`o1v`)
+
+	h := NewHyphenator(patterns)
+	words := map[string]string{
+		`COVID`:                   `CO-VID`,
+		`(COVID-19).`:             `(COVID-19).`,
+		`19-COVID`:                `19-COVID`,
+		`covid19`:                 `covid19`,
+		`(hyphenation),`:          `(hy-phen-ation),`,
+		`hyphenation's`:           `hy-phen-ation's`,
+		`hyphenation-hyphenation`: `hy-phen-ation-hy-phen-ation`,
+		`e-mail`:                  `e-mail`,
+		`COVID-19`:                `COVID-19`,
+	}
+	for word, expected := range words {
+		if s := h.Hyphenate(word, `-`); s != expected {
+			t.Errorf("Expected '%s' to hyphenate as '%s', got '%s'", word, expected, s)
+		}
+	}
+
+	breaks := h.Breaks(`e-mail`)
+	if breaks.Len() != 1 || breaks.At(0).(*Break).Offset != 2 || !breaks.At(0).(*Break).Explicit {
+		t.Errorf("Expected an explicit break after the hyphen in 'e-mail', got %v", *breaks)
+	}
+	if breaks := h.Breaks(`COVID-19`); breaks.Len() != 0 {
+		t.Errorf("Expected no breaks in a word containing digits, got %v", *breaks)
+	}
+
+	// no break is allowed beside an ignored apostrophe
+	h.LeftMin, h.RightMin = 1, 1
+	if s := h.Hyphenate(`don't`, `-`); s != `don't` {
+		t.Errorf("Expected no break beside the apostrophe in 'don't', got '%s'", s)
+	}
+
+	h.LeftMin, h.RightMin = 2, 2
+	h.Apostrophes = ApostropheSplit
+	if s := h.Hyphenate(`l'homme`, `-`); s != `l'hom-me` {
+		t.Errorf("Expected 'l'hom-me', got '%s'", s)
+	}
+
+	// breaks mapped past an ignored apostrophe stay in order, even beside reordered marks
+	marks := NewTrie()
+	for _, pattern := range []string{`b1x`, "\u03161", "\u03011", "1\u0316", "1\u0301", "x1"} {
+		marks.AddPatternString(pattern)
+	}
+	h = NewHyphenator(marks)
+	h.LeftMin, h.RightMin = 1, 1
+	if points := h.Points("b'x\u0301\u0316y"); points.Len() != 2 || points.At(0) != 3 || points.At(1) != 5 {
+		t.Errorf("Expected breaks [3 5], got %v", *points)
+	}
+}
@@ -0,0 +1,143 @@
+/*
+ * iterate.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"utf8"
+)
+
+// A keyBuffer holds the UTF-8 bytes of the key being built during a traversal, so that it can be
+// extended and shortened without allocating a new string at every node.
+type keyBuffer struct {
+	bytes []byte
+}
+
+// Appends a rune to the key, returning its length in bytes.
+func (k *keyBuffer) push(rune int) int {
+	n := len(k.bytes)
+	if n+utf8.UTFMax > cap(k.bytes) {
+		b := make([]byte, n, 2*cap(k.bytes)+utf8.UTFMax)
+		copy(b, k.bytes)
+		k.bytes = b
+	}
+
+	size := utf8.EncodeRune(rune, k.bytes[n:n+utf8.UTFMax])
+	k.bytes = k.bytes[0 : n+size]
+	return size
+}
+
+// Removes the given number of bytes from the end of the key.
+func (k *keyBuffer) pop(size int) {
+	k.bytes = k.bytes[0 : len(k.bytes)-size]
+}
+
+// Internal traversal function: calls yield with each member string below this node and its value, in
+// lexicographic rune order.  Returns false if yield asked to stop.
+func (p *Trie) each(key *keyBuffer, yield func(string, interface{}) bool) bool {
+	if p.leaf && !yield(string(key.bytes), p.value) {
+		return false
+	}
+
+	for _, rune := range p.runes {
+		size := key.push(rune)
+		if !p.children[rune].each(key, yield) {
+			return false
+		}
+		key.pop(size)
+	}
+	return true
+}
+
+// Internal function: returns the node reached by following the runes of s, whether or not it is a leaf,
+// or nil if there is none.
+func (p *Trie) node(s string) *Trie {
+	for _, rune := range s {
+		child, ok := p.children[rune]
+		if !ok {
+			return nil
+		}
+		p = child
+	}
+	return p
+}
+
+// Returns an iterator over every member string and its value, in lexicographic rune order.  The iterator
+// calls yield with each pair in turn, stopping early if yield returns false; members are found as the
+// iteration proceeds, rather than all at once.  The trie mustn't be changed during an iteration.
+func (p *Trie) All() func(yield func(key string, value interface{}) bool) {
+	return func(yield func(string, interface{}) bool) {
+		p.each(new(keyBuffer), yield)
+	}
+}
+
+// Returns an iterator over every member string, in lexicographic rune order, as All().
+func (p *Trie) Keys() func(yield func(key string) bool) {
+	return func(yield func(string) bool) {
+		p.each(new(keyBuffer), func(key string, value interface{}) bool {
+			return yield(key)
+		})
+	}
+}
+
+// Returns an iterator over the value of every member string, in the lexicographic rune order of the
+// strings, as All().
+func (p *Trie) Values() func(yield func(value interface{}) bool) {
+	return func(yield func(interface{}) bool) {
+		p.each(new(keyBuffer), func(key string, value interface{}) bool {
+			return yield(value)
+		})
+	}
+}
+
+// Returns an iterator over every member string beginning with the given prefix, and its value, in
+// lexicographic rune order, as All().
+func (p *Trie) Prefix(prefix string) func(yield func(key string, value interface{}) bool) {
+	return func(yield func(string, interface{}) bool) {
+		n := p.node(prefix)
+		if n == nil {
+			return
+		}
+
+		key := new(keyBuffer)
+		for _, rune := range prefix {
+			key.push(rune)
+		}
+		n.each(key, yield)
+	}
+}
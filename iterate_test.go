@@ -0,0 +1,110 @@
+/*
+ * iterate_test.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"testing"
+	"container/vector"
+)
+
+func TestIterators(t *testing.T) {
+	trie := NewTrie()
+	trie.AddValue(`tea`, 1)
+	trie.AddValue(`ten`, 2)
+	trie.AddValue(`to`, 3)
+	trie.AddValue(`té`, 4)
+	trie.AddValue(`inn`, 5)
+	trie.AddString(`te`)
+
+	keys := new(vector.StringVector)
+	values := new(vector.Vector)
+	trie.All()(func(key string, value interface{}) bool {
+		keys.Push(key)
+		values.Push(value)
+		return true
+	})
+
+	expected := &vector.StringVector{`inn`, `te`, `tea`, `ten`, `to`, `té`}
+	if keys.Len() != expected.Len() {
+		t.Fatalf("Expected keys %v, got %v", *expected, *keys)
+	}
+	for i := 0; i < keys.Len(); i++ {
+		if keys.At(i) != expected.At(i) {
+			t.Fatalf("Expected keys %v, got %v", *expected, *keys)
+		}
+	}
+	if values.At(0) != 5 || values.At(1) != nil || values.At(5) != 4 {
+		t.Errorf("Unexpected values %v", *values)
+	}
+
+	// stopping early
+	count := 0
+	trie.Keys()(func(key string) bool {
+		count++
+		return key != `tea`
+	})
+	if count != 3 {
+		t.Errorf("Expected iteration to stop after 3 keys, got %d", count)
+	}
+
+	sum := 0
+	trie.Values()(func(value interface{}) bool {
+		if v, ok := value.(int); ok {
+			sum += v
+		}
+		return true
+	})
+	if sum != 15 {
+		t.Errorf("Expected values summing to 15, got %d", sum)
+	}
+
+	keys.Cut(0, keys.Len())
+	trie.Prefix(`te`)(func(key string, value interface{}) bool {
+		keys.Push(key)
+		return true
+	})
+	if keys.Len() != 3 || keys.At(0) != `te` || keys.At(2) != `ten` {
+		t.Errorf("Expected keys [te tea ten] with prefix 'te', got %v", *keys)
+	}
+
+	trie.Prefix(`x`)(func(key string, value interface{}) bool {
+		t.Errorf("Unexpected key '%s' with prefix 'x'", key)
+		return true
+	})
+}
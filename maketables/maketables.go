@@ -0,0 +1,317 @@
+/*
+ * maketables.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+// Generates normalize_tables.go from UnicodeData.txt and CompositionExclusions.txt in the Unicode
+// Character Database.  Run 'make tables' in the package directory to rebuild it, passing the directory
+// holding those files as UCD, and the Unicode version they belong to as UNICODE_VERSION.
+package main
+
+import (
+	"bufio"
+	"container/vector"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var data = flag.String("data", "UnicodeData.txt", "the path of UnicodeData.txt")
+var exclusions = flag.String("exclusions", "CompositionExclusions.txt", "the path of CompositionExclusions.txt")
+var version = flag.String("version", "14.0.0", "the version of the Unicode Character Database")
+
+// The start of the generated file.
+const header = `/*
+ * normalize_tables.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+`
+
+// The canonical combining class of each rune which has one other than zero.
+var combiningClasses = make(map[int]int)
+
+// The canonical decomposition mapping of each rune which has one, as listed in UnicodeData.txt.
+var mappings = make(map[int][]int)
+
+// The runes listed in CompositionExclusions.txt.
+var excluded = make(map[int]bool)
+
+// Reports an error and exits.
+func fatal(s string) {
+	fmt.Fprintln(os.Stderr, "maketables: "+s)
+	os.Exit(1)
+}
+
+// Parses a hexadecimal code point.
+func parseRune(s string) int {
+	n, err := strconv.Btoui64(s, 16)
+	if err != nil {
+		fatal("invalid code point " + s)
+	}
+	return int(n)
+}
+
+// Calls f with the fields of each line of a UCD file, skipping comments and blank lines.
+func readFile(name string, f func(fields []string)) {
+	file, err := os.Open(name, os.O_RDONLY, 0)
+	if err != nil {
+		fatal(err.String())
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && err != os.EOF {
+			fatal(err.String())
+		}
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[0:i]
+		}
+		if line = strings.TrimSpace(line); len(line) != 0 {
+			fields := strings.Split(line, ";", -1)
+			for i := range fields {
+				fields[i] = strings.TrimSpace(fields[i])
+			}
+			f(fields)
+		}
+		if err == os.EOF {
+			break
+		}
+	}
+}
+
+// Reads the combining classes and canonical decompositions from UnicodeData.txt.  Ranges such as the
+// Hangul syllables have neither, so only single code points are considered.
+func readData() {
+	readFile(*data, func(fields []string) {
+		if len(fields) < 6 {
+			fatal("malformed line in " + *data)
+		}
+		rune := parseRune(fields[0])
+
+		if class, _ := strconv.Atoi(fields[3]); class != 0 {
+			combiningClasses[rune] = class
+		}
+
+		// compatibility decompositions begin with a tag such as '<font>'
+		if len(fields[5]) != 0 && fields[5][0] != '<' {
+			decomposition := strings.Fields(fields[5])
+			mapping := make([]int, len(decomposition))
+			for i, s := range decomposition {
+				mapping[i] = parseRune(s)
+			}
+			mappings[rune] = mapping
+		}
+	})
+}
+
+// Reads the runes excluded from composition from CompositionExclusions.txt.
+func readExclusions() {
+	readFile(*exclusions, func(fields []string) {
+		excluded[parseRune(fields[0])] = true
+	})
+}
+
+// Returns the full canonical decomposition of a rune, in canonical order.
+func decompose(rune int) *vector.IntVector {
+	runes := new(vector.IntVector)
+	mapping, ok := mappings[rune]
+	if !ok {
+		runes.Push(rune)
+		return runes
+	}
+
+	for _, r := range mapping {
+		runes.AppendVector(decompose(r))
+	}
+
+	// a stable sort of each run of non-starters by combining class
+	for i := 1; i < runes.Len(); i++ {
+		for j := i; j > 0; j-- {
+			class := combiningClasses[runes.At(j)]
+			if class == 0 || combiningClasses[runes.At(j-1)] <= class {
+				break
+			}
+			runes.Swap(j-1, j)
+		}
+	}
+	return runes
+}
+
+// Whether a rune is a primary composite: its mapping is a pair of runes beginning with a starter, and
+// it is neither a non-starter nor listed in CompositionExclusions.txt.
+func isPrimaryComposite(rune int) bool {
+	mapping := mappings[rune]
+	return len(mapping) == 2 && !excluded[rune] && combiningClasses[rune] == 0 &&
+		combiningClasses[mapping[0]] == 0
+}
+
+// Returns a code point in hexadecimal, as in the generated tables.
+func hex(rune int) string {
+	return fmt.Sprintf("0x%04x", rune)
+}
+
+// Returns a code point as an escape within a string literal.
+func escape(rune int) string {
+	if rune > 0xffff {
+		return fmt.Sprintf("\\U%08x", rune)
+	}
+	return fmt.Sprintf("\\u%04x", rune)
+}
+
+// Returns the keys of a map, in order.
+func sortedKeys(m map[int]bool) *vector.IntVector {
+	keys := new(vector.IntVector)
+	for key := range m {
+		keys.Push(key)
+	}
+	sort.Sort(keys)
+	return keys
+}
+
+// Prints a table's entries, the given number to a line.
+func printEntries(entries *vector.StringVector, perLine int) {
+	for i := 0; i < entries.Len(); i += perLine {
+		end := min(i+perLine, entries.Len())
+		fmt.Printf("\t%s,\n", strings.Join(entries.Slice(i, end).Data(), ", "))
+	}
+}
+
+// Returns the smaller of two integers.
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func main() {
+	flag.Parse()
+	readData()
+	readExclusions()
+
+	fmt.Print(header)
+	fmt.Print("// The tables below are generated by maketables/maketables.go from UnicodeData.txt and\n")
+	fmt.Printf("// CompositionExclusions.txt in version %s of the Unicode Character Database; run 'make tables' to\n", *version)
+	fmt.Print("// rebuild them.  Hangul syllables are decomposed and composed arithmetically instead.\n\n")
+
+	classes := make(map[int]bool)
+	for rune := range combiningClasses {
+		classes[rune] = true
+	}
+	keys := sortedKeys(classes)
+	entries := new(vector.StringVector)
+	for i := 0; i < keys.Len(); i++ {
+		rune := keys.At(i)
+		entries.Push(fmt.Sprintf("%s: %d", hex(rune), combiningClasses[rune]))
+	}
+	fmt.Print("// The canonical combining class of every rune which has one other than zero.\n")
+	fmt.Print("var combiningClasses = map[int]int{\n")
+	printEntries(entries, 6)
+	fmt.Print("}\n\n")
+
+	decomposable := make(map[int]bool)
+	for rune := range mappings {
+		decomposable[rune] = true
+	}
+	keys = sortedKeys(decomposable)
+	entries = new(vector.StringVector)
+	for i := 0; i < keys.Len(); i++ {
+		rune := keys.At(i)
+		runes := decompose(rune)
+		parts := make([]string, runes.Len())
+		for j := range parts {
+			parts[j] = hex(runes.At(j))
+		}
+		entries.Push(fmt.Sprintf("%s: {%s}", hex(rune), strings.Join(parts, ", ")))
+	}
+	fmt.Print("// The full canonical decomposition of every rune which has one, in canonical order.\n")
+	fmt.Print("var decompositions = map[int][]int{\n")
+	printEntries(entries, 4)
+	fmt.Print("}\n\n")
+
+	entries = new(vector.StringVector)
+	for i := 0; i < keys.Len(); i++ {
+		if rune := keys.At(i); isPrimaryComposite(rune) {
+			mapping := mappings[rune]
+			entries.Push(fmt.Sprintf("\"%s%s\": %s", escape(mapping[0]), escape(mapping[1]), hex(rune)))
+		}
+	}
+	fmt.Print("// The primary composite of each pair of runes which canonically compose, keyed by the pair.\n")
+	fmt.Print("var compositions = map[string]int{\n")
+	printEntries(entries, 5)
+	fmt.Print("}\n")
+}
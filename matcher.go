@@ -0,0 +1,211 @@
+/*
+ * matcher.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"bufio"
+	"container/vector"
+	"io"
+	"os"
+	"utf8"
+)
+
+// A Matcher is an Aho-Corasick automaton compiled from the member strings of a Trie, which finds every
+// occurrence of every member within a text in a single pass.  It is not affected by later changes to
+// the Trie, and is safe for concurrent use.
+type Matcher struct {
+	nodes []*matcherNode // the states of the automaton; the first is the root.
+}
+
+// A state of a Matcher, corresponding to a node of the Trie from which it was compiled.
+type matcherNode struct {
+	next   map[int]int // the state reached on each rune, where the Trie has a child for it.
+	fail   int         // the state for the longest proper suffix of this state's string in the Trie.
+	output int         // the nearest state along the failure links which ends a member string, or -1.
+	leaf   bool        // whether this state ends a member string.
+	key    string      // the member string ending at this state, if any.
+	value  interface{} // the value associated with that string.
+	depth  int         // the number of runes in the string reaching this state.
+}
+
+// A Match records one occurrence of a member string within a text.
+type Match struct {
+	Start int         // the byte offset of the start of the match.
+	End   int         // the byte offset just after the end of the match.
+	Key   string      // the member string matched.
+	Value interface{} // the value associated with the member string.
+}
+
+// Compiles the member strings of the trie, and their values, into an Aho-Corasick automaton.  The empty
+// string, if it is a member, is never matched.
+func (p *Trie) Compile() *Matcher {
+	m := &Matcher{make([]*matcherNode, p.Size()+1)}
+
+	// number the states breadth-first, so each state's failure link is known before its children's
+	tries := make([]*Trie, len(m.nodes))
+	keys := make([]string, len(m.nodes))
+	tries[0] = p
+	m.nodes[0] = &matcherNode{make(map[int]int), 0, -1, false, ``, nil, 0}
+
+	count := 1
+	for i := 0; i < count; i++ {
+		node := m.nodes[i]
+		for _, rune := range tries[i].runes {
+			child := tries[i].children[rune]
+			tries[count] = child
+			keys[count] = keys[i] + string(rune)
+			m.nodes[count] = &matcherNode{make(map[int]int), 0, -1, child.leaf, ``, child.value,
+				node.depth + 1}
+			if child.leaf {
+				m.nodes[count].key = keys[count]
+			}
+			node.next[rune] = count
+
+			if i != 0 {
+				// follow the parent's failure links until one can be extended by this rune
+				f := node.fail
+				for {
+					if n, ok := m.nodes[f].next[rune]; ok {
+						m.nodes[count].fail = n
+						break
+					}
+					if f == 0 {
+						break
+					}
+					f = m.nodes[f].fail
+				}
+			}
+
+			fail := m.nodes[m.nodes[count].fail]
+			if fail.leaf {
+				m.nodes[count].output = m.nodes[count].fail
+			} else {
+				m.nodes[count].output = fail.output
+			}
+			count++
+		}
+	}
+
+	return m
+}
+
+// Internal function: returns the state reached from the given state on a rune.
+func (m *Matcher) step(state, rune int) int {
+	for {
+		if n, ok := m.nodes[state].next[rune]; ok {
+			return n
+		}
+		if state == 0 {
+			return 0
+		}
+		state = m.nodes[state].fail
+	}
+	panic(`unreachable`)
+}
+
+// Internal function: adds the matches ending in the given state, longest first.  The start function
+// gives the byte offset at which each match begins.
+func (m *Matcher) addMatches(matches *vector.Vector, state, end int, start func(*matcherNode) int) {
+	if !m.nodes[state].leaf {
+		state = m.nodes[state].output
+	}
+	for ; state > 0; state = m.nodes[state].output {
+		node := m.nodes[state]
+		matches.Push(&Match{start(node), end, node.key, node.value})
+	}
+}
+
+// Finds every occurrence of every member string within s, including overlapping ones.  The elements of
+// the returned vector are of type *Match, ordered by their end offsets, with longer matches first among
+// those ending at the same offset.
+func (m *Matcher) FindAll(s string) *vector.Vector {
+	return m.findAll(s, nil)
+}
+
+// Internal function: finds every occurrence of every member string within s, passing each rune of s
+// through the mapping function first, if there is one.
+func (m *Matcher) findAll(s string, mapping func(int) int) *vector.Vector {
+	matches := new(vector.Vector)
+
+	// the byte offset of each rune, since mapped runes may differ in length from the originals
+	offsets := new(vector.IntVector)
+	start := func(node *matcherNode) int {
+		return offsets.At(offsets.Len() - node.depth)
+	}
+
+	state := 0
+	for pos := 0; pos < len(s); {
+		rune, size := utf8.DecodeRuneInString(s[pos:])
+		if mapping != nil {
+			rune = mapping(rune)
+		}
+		offsets.Push(pos)
+		pos += size
+		state = m.step(state, rune)
+		m.addMatches(matches, state, pos, start)
+	}
+	return matches
+}
+
+// Finds every occurrence of every member string within the text read from r, as FindAll().  Offsets
+// are counted in bytes from the start of the reader.
+func (m *Matcher) FindAllReader(r io.Reader) (*vector.Vector, os.Error) {
+	matches := new(vector.Vector)
+	reader := bufio.NewReader(r)
+
+	state, end := 0, 0
+	start := func(node *matcherNode) int {
+		return end - len(node.key)
+	}
+
+	for {
+		rune, size, err := reader.ReadRune()
+		if err == os.EOF {
+			break
+		} else if err != nil {
+			return matches, err
+		}
+
+		end += size
+		state = m.step(state, rune)
+		m.addMatches(matches, state, end, start)
+	}
+
+	return matches, nil
+}
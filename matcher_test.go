@@ -0,0 +1,90 @@
+/*
+ * matcher_test.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"testing"
+	"container/vector"
+	"strings"
+)
+
+func checkMatches(matches, expected *vector.Vector, t *testing.T) {
+	if matches.Len() != expected.Len() {
+		t.Errorf("Expected %d matches, got %d", expected.Len(), matches.Len())
+		return
+	}
+	for i := 0; i < matches.Len(); i++ {
+		m, e := matches.At(i).(*Match), expected.At(i).(*Match)
+		if m.Start != e.Start || m.End != e.End || m.Key != e.Key || m.Value != e.Value {
+			t.Errorf("Expected match %v, got %v", *e, *m)
+		}
+	}
+}
+
+func TestMatcher(t *testing.T) {
+	trie := NewTrie()
+	trie.AddValue(`he`, 1)
+	trie.AddValue(`she`, 2)
+	trie.AddValue(`his`, 3)
+	trie.AddValue(`hers`, 4)
+	trie.AddValue(`ßhe`, 5)
+
+	m := trie.Compile()
+	expected := &vector.Vector{
+		&Match{1, 4, `she`, 2},
+		&Match{2, 4, `he`, 1},
+		&Match{2, 6, `hers`, 4},
+		&Match{7, 11, `ßhe`, 5},
+		&Match{9, 11, `he`, 1},
+		&Match{12, 15, `his`, 3},
+	}
+
+	text := `ushers ßhe his`
+	checkMatches(m.FindAll(text), expected, t)
+
+	matches, err := m.FindAllReader(strings.NewReader(text))
+	if err != nil {
+		t.Fatalf("FindAllReader failed: %s", err)
+	}
+	checkMatches(matches, expected, t)
+
+	// the matcher is independent of the trie once compiled
+	trie.Remove(`hers`)
+	checkMatches(m.FindAll(text), expected, t)
+}
@@ -0,0 +1,222 @@
+/*
+ * merge.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"container/vector"
+	"os"
+	"sort"
+	"strings"
+)
+
+// A ConflictPolicy determines how MergePatterns() resolves a letter sequence present in both tries
+// with different digit vectors.
+type ConflictPolicy int
+
+const (
+	MergeMax        ConflictPolicy = iota // take the larger digit at each position, as TeX would.
+	PreferLeft                            // keep the receiver's digits.
+	PreferRight                           // take the other trie's digits.
+	ErrorOnConflict                       // fail without changing the receiver.
+)
+
+// A PatternConflict records a letter sequence which has different digit vectors in two pattern sets.
+type PatternConflict struct {
+	Letters string
+	Left    *vector.IntVector // the digits in the left (receiving or old) pattern set.
+	Right   *vector.IntVector // the digits in the right (merged or new) pattern set.
+}
+
+// Returns both patterns in TeX form.
+func (c *PatternConflict) String() string {
+	return formatPattern(c.Letters, c.Left) + ` conflicts with ` + formatPattern(c.Letters, c.Right)
+}
+
+// The error returned by MergePatterns() under the ErrorOnConflict policy.
+type ConflictError struct {
+	Conflicts *vector.Vector // elements of type *PatternConflict.
+}
+
+func (e *ConflictError) String() string {
+	s := make([]string, e.Conflicts.Len())
+	for i := range s {
+		s[i] = e.Conflicts.At(i).(*PatternConflict).String()
+	}
+	return `pattern conflicts: ` + strings.Join(s, `; `)
+}
+
+// A MergeReport lists the patterns found in both tries during a merge.
+type MergeReport struct {
+	Duplicates *vector.StringVector // patterns present in both tries with equivalent digits.
+	Conflicts  *vector.Vector       // elements of type *PatternConflict.
+}
+
+// A PatternDiff lists the differences between two pattern sets.
+type PatternDiff struct {
+	Added   *vector.StringVector // patterns, in TeX form, present only in the new set.
+	Removed *vector.StringVector // patterns, in TeX form, present only in the old set.
+	Changed *vector.Vector       // elements of type *PatternConflict, with the old digits on the left.
+}
+
+// Internal type used to sort a vector of *PatternConflict by their letters.
+type conflictList struct {
+	*vector.Vector
+}
+
+func (c conflictList) Less(i, j int) bool {
+	return c.At(i).(*PatternConflict).Letters < c.At(j).(*PatternConflict).Letters
+}
+
+// Internal function: returns the digit vector stored at a leaf, or an empty vector if it holds none.
+func (p *Trie) patternValues() *vector.IntVector {
+	if v, ok := p.value.(*vector.IntVector); ok {
+		return v
+	}
+	return new(vector.IntVector)
+}
+
+// Internal function: returns the i'th element of a digit vector counting back from its last element,
+// which always belongs to the last letter of the pattern.  Positions beyond the vector hold an
+// implied zero.
+func valueFromEnd(v *vector.IntVector, i int) int {
+	if i >= v.Len() {
+		return 0
+	}
+	return v.At(v.Len() - 1 - i)
+}
+
+// Internal function: combines two digit vectors for the same letters, taking the larger value at each
+// position.
+func mergeValues(a, b *vector.IntVector) *vector.IntVector {
+	n := a.Len()
+	if b.Len() > n {
+		n = b.Len()
+	}
+
+	v := new(vector.IntVector)
+	for i := n - 1; i >= 0; i-- {
+		x, y := valueFromEnd(a, i), valueFromEnd(b, i)
+		if y > x {
+			x = y
+		}
+		v.Push(x)
+	}
+	return v
+}
+
+// Internal function: whether two digit vectors for the same letters are equivalent, treating a prefix
+// value of zero the same as no prefix value at all.
+func equalValues(a, b *vector.IntVector) bool {
+	for i := 0; i < a.Len() || i < b.Len(); i++ {
+		if valueFromEnd(a, i) != valueFromEnd(b, i) {
+			return false
+		}
+	}
+	return true
+}
+
+// Merges the patterns of another trie into this one.  Patterns found only in the other trie are copied
+// across, and those found in both are resolved according to the given policy.  The returned report lists
+// every pattern found in both tries; under ErrorOnConflict, any conflict causes a *ConflictError to be
+// returned and the receiver is left unchanged.
+func (p *Trie) MergePatterns(other *Trie, policy ConflictPolicy) (*MergeReport, os.Error) {
+	report := &MergeReport{new(vector.StringVector), new(vector.Vector)}
+	additions := make(map[string]*vector.IntVector)
+
+	other.walk(``, func(key string, theirs *Trie) {
+		right := theirs.patternValues()
+		mine := p.includes(strings.NewReader(key))
+		if mine == nil {
+			// copy the digits, so the two tries don't share them
+			additions[key] = mergeValues(right, right)
+			return
+		}
+
+		left := mine.patternValues()
+		if equalValues(left, right) {
+			report.Duplicates.Push(formatPattern(key, left))
+			return
+		}
+
+		report.Conflicts.Push(&PatternConflict{key, left, right})
+		switch policy {
+		case MergeMax:
+			additions[key] = mergeValues(left, right)
+		case PreferRight:
+			additions[key] = mergeValues(right, right)
+		}
+	})
+
+	sort.Sort(report.Duplicates)
+	sort.Sort(conflictList{report.Conflicts})
+	if policy == ErrorOnConflict && report.Conflicts.Len() != 0 {
+		return report, &ConflictError{report.Conflicts}
+	}
+
+	for key, v := range additions {
+		p.AddValue(key, v)
+	}
+	return report, nil
+}
+
+// Compares two pattern sets, listing the patterns added, removed and changed in going from the old
+// set to the new one.
+func DiffPatterns(from, to *Trie) *PatternDiff {
+	diff := &PatternDiff{new(vector.StringVector), new(vector.StringVector), new(vector.Vector)}
+
+	from.walk(``, func(key string, old *Trie) {
+		if to.includes(strings.NewReader(key)) == nil {
+			diff.Removed.Push(formatPattern(key, old.patternValues()))
+		}
+	})
+
+	to.walk(``, func(key string, node *Trie) {
+		old := from.includes(strings.NewReader(key))
+		if old == nil {
+			diff.Added.Push(formatPattern(key, node.patternValues()))
+		} else if !equalValues(old.patternValues(), node.patternValues()) {
+			diff.Changed.Push(&PatternConflict{key, old.patternValues(), node.patternValues()})
+		}
+	})
+
+	sort.Sort(diff.Added)
+	sort.Sort(diff.Removed)
+	sort.Sort(conflictList{diff.Changed})
+	return diff
+}
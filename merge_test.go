@@ -0,0 +1,150 @@
+/*
+ * merge_test.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"testing"
+	"container/vector"
+)
+
+func TestMergePatterns(t *testing.T) {
+	base := NewTrie()
+	base.AddPatternString(`hy3ph`)
+	base.AddPatternString(`he2n`)
+
+	custom := NewTrie()
+	custom.AddPatternString(`hy3ph`)
+	custom.AddPatternString(`h1en`)
+	custom.AddPatternString(`hen5at`)
+
+	// the conflict should prevent any change
+	report, err := base.MergePatterns(custom, ErrorOnConflict)
+	if err == nil {
+		t.Fatal("Expected a conflict error merging 'he2n' with 'h1en'")
+	}
+	if _, ok := err.(*ConflictError); !ok {
+		t.Errorf("Expected a *ConflictError, got %v", err)
+	}
+	if base.Contains(`henat`) {
+		t.Error("A failed merge should leave the receiver unchanged")
+	}
+
+	if report.Duplicates.Len() != 1 || report.Duplicates.At(0) != `hy3ph` {
+		t.Errorf("Expected 'hy3ph' to be reported as a duplicate, got %v", *report.Duplicates)
+	}
+	if report.Conflicts.Len() != 1 {
+		t.Fatalf("Expected one conflict, got %d", report.Conflicts.Len())
+	}
+	if c := report.Conflicts.At(0).(*PatternConflict); c.String() != `he2n conflicts with h1en` {
+		t.Errorf("Unexpected conflict '%s'", c)
+	}
+
+	left := NewTrie()
+	left.AddPatternString(`he2n`)
+	left.MergePatterns(custom, PreferLeft)
+	checkValues(left, `hen`, &vector.IntVector{0, 2, 0}, t)
+	checkValues(left, `henat`, &vector.IntVector{0, 0, 5, 0, 0}, t)
+
+	right := NewTrie()
+	right.AddPatternString(`he2n`)
+	right.MergePatterns(custom, PreferRight)
+	checkValues(right, `hen`, &vector.IntVector{1, 0, 0}, t)
+
+	base.MergePatterns(custom, MergeMax)
+	checkValues(base, `hen`, &vector.IntVector{1, 2, 0}, t)
+	if base.Members().Len() != 3 {
+		t.Errorf("Expected 3 patterns after merging, got %v", *base.Members())
+	}
+}
+
+func TestDiffPatterns(t *testing.T) {
+	from := NewTrie()
+	from.AddPatternString(`hy3ph`)
+	from.AddPatternString(`he2n`)
+	from.AddPatternString(`0hena4`)
+
+	to := NewTrie()
+	to.AddPatternString(`hena4`)
+	to.AddPatternString(`he3n`)
+	to.AddPatternString(`hen5at`)
+
+	diff := DiffPatterns(from, to)
+	if diff.Added.Len() != 1 || diff.Added.At(0) != `hen5at` {
+		t.Errorf("Expected 'hen5at' to be added, got %v", *diff.Added)
+	}
+	if diff.Removed.Len() != 1 || diff.Removed.At(0) != `hy3ph` {
+		t.Errorf("Expected 'hy3ph' to be removed, got %v", *diff.Removed)
+	}
+	if diff.Changed.Len() != 1 {
+		t.Fatalf("Expected only 'he2n' to change, got %d changes", diff.Changed.Len())
+	}
+	if c := diff.Changed.At(0).(*PatternConflict); c.Letters != `hen` {
+		t.Errorf("Expected 'hen' to change, got '%s'", c.Letters)
+	}
+}
+
+func TestMergeWithoutDigits(t *testing.T) {
+	// a member added without a digit vector has an implied zero at every position
+	plain := NewTrie()
+	plain.AddString(`hen`)
+	plain.AddString(`tion`)
+
+	patterns := NewTrie()
+	patterns.AddPatternString(`he2n`)
+
+	diff := DiffPatterns(patterns, plain)
+	if diff.Added.Len() != 1 || diff.Added.At(0) != `tion` {
+		t.Errorf("Expected 'tion' to be added, got %v", *diff.Added)
+	}
+	if diff.Changed.Len() != 1 || diff.Changed.At(0).(*PatternConflict).String() != `he2n conflicts with hen` {
+		t.Errorf("Expected 'he2n' to change to 'hen', got %v", *diff.Changed)
+	}
+
+	report, err := patterns.MergePatterns(plain, MergeMax)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if report.Conflicts.Len() != 1 {
+		t.Errorf("Expected 1 conflict, got %d", report.Conflicts.Len())
+	}
+	strs := patterns.PatternStrings()
+	if strs.Len() != 2 || strs.At(0) != `he2n` || strs.At(1) != `tion` {
+		t.Errorf("Expected merged patterns [he2n tion], got %v", *strs)
+	}
+}
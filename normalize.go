@@ -0,0 +1,183 @@
+/*
+ * normalize.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"container/vector"
+	"unicode"
+)
+
+// Ligatures which are expanded into their component letters before lookup.
+var ligatures = map[int]string{
+	'ﬀ': `ff`,
+	'ﬁ': `fi`,
+	'ﬂ': `fl`,
+	'ﬃ': `ffi`,
+	'ﬄ': `ffl`,
+	'ﬅ': `st`,
+	'ﬆ': `st`,
+}
+
+// Constants for the arithmetic decomposition and composition of Hangul syllables.
+const (
+	hangulBase   = 0xac00 // the first precomposed syllable.
+	hangulL      = 0x1100 // the first leading consonant jamo.
+	hangulV      = 0x1161 // the first vowel jamo.
+	hangulT      = 0x11a7 // one before the first trailing consonant jamo.
+	hangulLCount = 19
+	hangulVCount = 21
+	hangulTCount = 28
+	hangulCount  = hangulLCount * hangulVCount * hangulTCount
+)
+
+// Internal function: appends the canonical decomposition of a rune to runes, and its origin to origins
+// once for each rune appended.
+func decompose(rune, origin int, runes, origins *vector.IntVector) {
+	if s := rune - hangulBase; s >= 0 && s < hangulCount {
+		runes.Push(hangulL + s/(hangulVCount*hangulTCount))
+		origins.Push(origin)
+		runes.Push(hangulV + s%(hangulVCount*hangulTCount)/hangulTCount)
+		origins.Push(origin)
+		if t := s % hangulTCount; t != 0 {
+			runes.Push(hangulT + t)
+			origins.Push(origin)
+		}
+		return
+	}
+
+	d, ok := decompositions[rune]
+	if !ok {
+		d = []int{rune}
+	}
+	for _, r := range d {
+		runes.Push(r)
+		origins.Push(origin)
+	}
+}
+
+// Internal function: sorts each run of combining marks into canonical order by combining class, moving
+// their origins with them.
+func reorderMarks(runes, origins *vector.IntVector) {
+	for i := 1; i < runes.Len(); i++ {
+		class := combiningClasses[runes.At(i)]
+		for j := i; class != 0 && j > 0 && combiningClasses[runes.At(j-1)] > class; j-- {
+			runes.Swap(j-1, j)
+			origins.Swap(j-1, j)
+		}
+	}
+}
+
+// Internal function: returns the primary composite of two runes, if they have one.
+func compose(a, b int) (int, bool) {
+	if l, v := a-hangulL, b-hangulV; l >= 0 && l < hangulLCount && v >= 0 && v < hangulVCount {
+		return hangulBase + (l*hangulVCount+v)*hangulTCount, true
+	}
+	if s, t := a-hangulBase, b-hangulT; s >= 0 && s < hangulCount && s%hangulTCount == 0 && t > 0 &&
+		t < hangulTCount {
+		return a + t, true
+	}
+
+	composed, ok := compositions[string([]int{a, b})]
+	return composed, ok
+}
+
+// Internal function: composes each decomposed, canonically ordered rune with the last starter before it
+// where possible, as in the canonical composition algorithm of Unicode Standard Annex #15.  A composed
+// rune takes the origin of its starter.
+func composeMarks(runes, origins *vector.IntVector) (*vector.IntVector, *vector.IntVector) {
+	composed := new(vector.IntVector)
+	composedOrigins := new(vector.IntVector)
+
+	starter := -1
+	for i := 0; i < runes.Len(); i++ {
+		rune := runes.At(i)
+		class := combiningClasses[rune]
+		if starter >= 0 {
+			// a rune is blocked from its starter by any rune between them of the same or higher class
+			last := combiningClasses[composed.Last()]
+			if composed.Len()-1 == starter || (last != 0 && last < class) {
+				if r, ok := compose(composed.At(starter), rune); ok {
+					composed.Set(starter, r)
+					continue
+				}
+			}
+		}
+
+		if class == 0 {
+			starter = composed.Len()
+		}
+		composed.Push(rune)
+		composedOrigins.Push(origins.At(i))
+	}
+
+	return composed, composedOrigins
+}
+
+// Internal function: normalizes a word for lookup in a pattern trie.  Ligatures are expanded, the word
+// is case-folded, and the result is put into Normalization Form C.  Returns the normalized word and, for
+// each of its runes, the rune offset within the original word of the rune from which it came.
+func normalizeWord(word string) (string, []int) {
+	runes := new(vector.IntVector)
+	origins := new(vector.IntVector)
+
+	i := 0
+	for _, rune := range word {
+		if expansion, ok := ligatures[rune]; ok {
+			for _, r := range expansion {
+				runes.Push(r)
+				origins.Push(i)
+			}
+		} else {
+			decompose(unicode.ToLower(rune), i, runes, origins)
+		}
+		i++
+	}
+
+	reorderMarks(runes, origins)
+	composed, composedOrigins := composeMarks(runes, origins)
+	return string(composed.Data()), composedOrigins.Data()
+}
+
+// Returns the form of a word used for lookup in a pattern trie: ligatures such as 'ﬁ' are expanded,
+// the word is case-folded, and it is put into Normalization Form C, so that decomposed letters and
+// stacked combining marks match the precomposed letters of the patterns.
+func NormalizeWord(word string) string {
+	s, _ := normalizeWord(word)
+	return s
+}
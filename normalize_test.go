@@ -0,0 +1,112 @@
+/*
+ * normalize_test.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"testing"
+)
+
+func TestNormalizeWord(t *testing.T) {
+	words := map[string]string{
+		`HYPHENATION`:    `hyphenation`,
+		"Pe\u0301tanque": `pétanque`,
+		"ﬁnal":           `final`,
+		"A\u0308RGER":    `ärger`,
+
+		// Cyrillic, Greek, stacked marks in either order, and Hangul jamo
+		"И\u0306ТИ":          "\u0439ти",
+		"е\u0308лка":         "\u0451лка",
+		"Α\u0301λφα":         "\u03acλφα",
+		"ve\u0323\u0302t":    "v\u1ec7t",
+		"ve\u0302\u0323t":    "v\u1ec7t",
+		"\u1100\u1161\u11a8": "\uac01",
+	}
+	for word, expected := range words {
+		if s := NormalizeWord(word); s != expected {
+			t.Errorf("Expected '%s' to normalize to '%s', got '%s'", word, expected, s)
+		}
+	}
+
+	// each composed rune comes from the first rune of its decomposed form
+	s, origins := normalizeWord("Ve\u0323\u0302t")
+	if s != "v\u1ec7t" || len(origins) != 3 || origins[0] != 0 || origins[1] != 1 || origins[2] != 4 {
+		t.Errorf("Expected 'v\u1ec7t' with origins [0 1 4], got '%s' with %v", s, origins)
+	}
+}
+
+func TestNormalizedHyphenation(t *testing.T) {
+	patterns := NewTrie()
+	patterns.AddPatternString(`hy3ph`)
+	patterns.AddPatternString(`hen5at`)
+	patterns.AddPatternString(`é1t`)
+	patterns.AddPatternString(`e1f`)
+	patterns.AddPatternString(`f1i`)
+
+	h := NewHyphenator(patterns)
+	words := map[string]string{
+		`HYPHENATION`:    `HY-PHEN-ATION`,
+		"Pe\u0301tanque": "Pe\u0301-tanque",
+		"deﬁned":         "de-ﬁned",
+	}
+	for word, expected := range words {
+		if s := h.Hyphenate(word, `-`); s != expected {
+			t.Errorf("Expected '%s' to hyphenate as '%s', got '%s'", word, expected, s)
+		}
+	}
+
+	// no break falls among marks which normalization reordered, and the rest stay in order
+	marks := NewTrie()
+	for _, pattern := range []string{"\u03161", "\u03011", "1\u0316", "1\u0301", "x1"} {
+		marks.AddPatternString(pattern)
+	}
+	h = NewHyphenator(marks)
+	h.LeftMin, h.RightMin = 1, 1
+	if points := h.Points("bx\u0301\u0316y"); points.Len() != 2 || points.At(0) != 2 || points.At(1) != 4 {
+		t.Errorf("Expected breaks [2 4] around the reordered marks, got %v", *points)
+	}
+	if s := h.Hyphenate("bx\u0301\u0316y", `-`); s != "bx-\u0301\u0316-y" {
+		t.Errorf("Expected 'bx-\u0301\u0316-y', got '%s'", s)
+	}
+
+	h = NewHyphenator(patterns)
+	h.Normalize = false
+	if s := h.Hyphenate(`HYPHENATION`, `-`); s != `HYPHENATION` {
+		t.Errorf("Expected no hyphenation without normalization, got '%s'", s)
+	}
+}
@@ -0,0 +1,203 @@
+/*
+ * ordered.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+// Internal function: finds the least member string at or below this node, adding its runes to the key.
+func (p *Trie) min(key *keyBuffer) (interface{}, bool) {
+	for !p.leaf {
+		if len(p.runes) == 0 {
+			return nil, false
+		}
+		rune := p.runes[0]
+		key.push(rune)
+		p = p.children[rune]
+	}
+	return p.value, true
+}
+
+// Internal function: finds the greatest member string at or below this node, adding its runes to the key.
+func (p *Trie) max(key *keyBuffer) (interface{}, bool) {
+	for len(p.children) != 0 {
+		rune := p.runes[len(p.runes)-1]
+		key.push(rune)
+		p = p.children[rune]
+	}
+	return p.value, p.leaf
+}
+
+// Internal function: finds the least member string greater than (or, if inclusive, equal to) the given
+// runes, below this node.  On success the runes of the member are added to the key.
+func (p *Trie) ceiling(target []int, inclusive bool, key *keyBuffer) (interface{}, bool) {
+	if len(target) == 0 {
+		if inclusive && p.leaf {
+			return p.value, true
+		}
+		// every longer string is greater
+		for _, rune := range p.runes {
+			size := key.push(rune)
+			if value, ok := p.children[rune].min(key); ok {
+				return value, true
+			}
+			key.pop(size)
+		}
+		return nil, false
+	}
+
+	for _, rune := range p.runes {
+		if rune < target[0] {
+			continue
+		}
+
+		mark := len(key.bytes)
+		key.push(rune)
+		var value interface{}
+		var ok bool
+		if rune == target[0] {
+			value, ok = p.children[rune].ceiling(target[1:], inclusive, key)
+		} else {
+			value, ok = p.children[rune].min(key)
+		}
+		if ok {
+			return value, true
+		}
+		key.pop(len(key.bytes) - mark)
+	}
+	return nil, false
+}
+
+// Internal function: finds the greatest member string less than (or, if inclusive, equal to) the given
+// runes, below this node.  On success the runes of the member are added to the key.
+func (p *Trie) floor(target []int, inclusive bool, key *keyBuffer) (interface{}, bool) {
+	if len(target) == 0 {
+		// every longer string is greater
+		if inclusive && p.leaf {
+			return p.value, true
+		}
+		return nil, false
+	}
+
+	for i := len(p.runes) - 1; i >= 0; i-- {
+		rune := p.runes[i]
+		if rune > target[0] {
+			continue
+		}
+
+		mark := len(key.bytes)
+		key.push(rune)
+		var value interface{}
+		var ok bool
+		if rune == target[0] {
+			value, ok = p.children[rune].floor(target[1:], inclusive, key)
+		} else {
+			value, ok = p.children[rune].max(key)
+		}
+		if ok {
+			return value, true
+		}
+		key.pop(len(key.bytes) - mark)
+	}
+
+	// this node's string is a proper prefix of the target, so is less than it
+	if p.leaf {
+		return p.value, true
+	}
+	return nil, false
+}
+
+// Returns the least member string in rune order, along with its value.  The boolean result is false if
+// the trie is empty.
+func (p *Trie) Min() (string, interface{}, bool) {
+	key := new(keyBuffer)
+	value, ok := p.min(key)
+	return string(key.bytes), value, ok
+}
+
+// Returns the greatest member string in rune order, along with its value.  The boolean result is false
+// if the trie is empty.
+func (p *Trie) Max() (string, interface{}, bool) {
+	key := new(keyBuffer)
+	value, ok := p.max(key)
+	return string(key.bytes), value, ok
+}
+
+// Returns the least member string greater than or equal to s, along with its value.  The boolean result
+// is false if there is none.
+func (p *Trie) Ceiling(s string) (string, interface{}, bool) {
+	key := new(keyBuffer)
+	value, ok := p.ceiling([]int(s), true, key)
+	return string(key.bytes), value, ok
+}
+
+// Returns the greatest member string less than or equal to s, along with its value.  The boolean result
+// is false if there is none.
+func (p *Trie) Floor(s string) (string, interface{}, bool) {
+	key := new(keyBuffer)
+	value, ok := p.floor([]int(s), true, key)
+	return string(key.bytes), value, ok
+}
+
+// Returns the least member string greater than s, along with its value.  The boolean result is false if
+// there is none.
+func (p *Trie) Next(s string) (string, interface{}, bool) {
+	key := new(keyBuffer)
+	value, ok := p.ceiling([]int(s), false, key)
+	return string(key.bytes), value, ok
+}
+
+// Returns the greatest member string less than s, along with its value.  The boolean result is false if
+// there is none.
+func (p *Trie) Prev(s string) (string, interface{}, bool) {
+	key := new(keyBuffer)
+	value, ok := p.floor([]int(s), false, key)
+	return string(key.bytes), value, ok
+}
+
+// Returns an iterator over the member strings from 'from' up to but not including 'to', and their values,
+// in rune order.  The iterator behaves as those returned by All().
+func (p *Trie) Range(from, to string) func(yield func(key string, value interface{}) bool) {
+	return func(yield func(string, interface{}) bool) {
+		key, value, ok := p.Ceiling(from)
+		for ok && key < to {
+			if !yield(key, value) {
+				return
+			}
+			key, value, ok = p.Next(key)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+/*
+ * ordered_test.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"testing"
+	"container/vector"
+)
+
+func TestOrderedLookups(t *testing.T) {
+	trie := NewTrie()
+	trie.AddValue(`tea`, 1)
+	trie.AddValue(`ten`, 2)
+	trie.AddValue(`to`, 3)
+	trie.AddValue(`té`, 4)
+	trie.AddValue(`inn`, 5)
+	trie.AddString(`te`)
+
+	if key, value, ok := trie.Min(); !ok || key != `inn` || value != 5 {
+		t.Errorf("Expected Min() to be inn/5, got %s/%v/%v", key, value, ok)
+	}
+	if key, value, ok := trie.Max(); !ok || key != `té` || value != 4 {
+		t.Errorf("Expected Max() to be té/4, got %s/%v/%v", key, value, ok)
+	}
+
+	// method values aren't available, so each lookup is wrapped in a closure
+	ceiling := func(s string) (string, interface{}, bool) { return trie.Ceiling(s) }
+	floor := func(s string) (string, interface{}, bool) { return trie.Floor(s) }
+	next := func(s string) (string, interface{}, bool) { return trie.Next(s) }
+	prev := func(s string) (string, interface{}, bool) { return trie.Prev(s) }
+
+	tests := []struct {
+		name   string
+		lookup func(string) (string, interface{}, bool)
+		in     string
+		out    string
+		found  bool
+	}{
+		{"Ceiling", ceiling, `te`, `te`, true},
+		{"Ceiling", ceiling, `teb`, `ten`, true},
+		{"Ceiling", ceiling, `a`, `inn`, true},
+		{"Ceiling", ceiling, `tz`, `té`, true},
+		{"Ceiling", ceiling, `u`, ``, false},
+		{"Floor", floor, `te`, `te`, true},
+		{"Floor", floor, `teb`, `tea`, true},
+		{"Floor", floor, `tez`, `ten`, true},
+		{"Floor", floor, `ta`, `inn`, true},
+		{"Floor", floor, `a`, ``, false},
+		{"Next", next, `te`, `tea`, true},
+		{"Next", next, `ten`, `to`, true},
+		{"Next", next, `té`, ``, false},
+		{"Prev", prev, `tea`, `te`, true},
+		{"Prev", prev, `te`, `inn`, true},
+		{"Prev", prev, `inn`, ``, false},
+	}
+	for _, test := range tests {
+		key, _, ok := test.lookup(test.in)
+		if ok != test.found || key != test.out {
+			t.Errorf("Expected %s(%s) to be %s/%v, got %s/%v", test.name, test.in, test.out, test.found, key, ok)
+		}
+	}
+
+	empty := NewTrie()
+	if _, _, ok := empty.Min(); ok {
+		t.Error("Expected Min() of an empty trie to fail")
+	}
+	if _, _, ok := empty.Max(); ok {
+		t.Error("Expected Max() of an empty trie to fail")
+	}
+}
+
+func TestRange(t *testing.T) {
+	trie := NewTrie()
+	trie.AddString(`sku-100`)
+	trie.AddString(`sku-150`)
+	trie.AddString(`sku-200`)
+	trie.AddString(`sku-250`)
+	trie.AddString(`sku-300`)
+
+	keys := new(vector.StringVector)
+	trie.Range(`sku-150`, `sku-300`)(func(key string, value interface{}) bool {
+		keys.Push(key)
+		return true
+	})
+
+	expected := &vector.StringVector{`sku-150`, `sku-200`, `sku-250`}
+	if keys.Len() != expected.Len() {
+		t.Fatalf("Expected range %v, got %v", *expected, *keys)
+	}
+	for i := 0; i < keys.Len(); i++ {
+		if keys.At(i) != expected.At(i) {
+			t.Fatalf("Expected range %v, got %v", *expected, *keys)
+		}
+	}
+}
@@ -0,0 +1,311 @@
+/*
+ * pattern.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"bufio"
+	"container/vector"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// A ParseMode determines how strictly pattern strings are checked.
+type ParseMode int
+
+const (
+	Lenient ParseMode = iota // repair malformed patterns where possible, reporting warnings.
+	Strict                   // reject malformed patterns.
+)
+
+// The kinds of problem which can be found in a pattern string.
+type PatternErrorKind int
+
+const (
+	EmptyPattern       PatternErrorKind = iota // the pattern contains no letters.
+	MultipleDigits                             // more than one digit appears between two letters.
+	DigitOutsideWord                           // a digit appears before a leading '.' or after a trailing '.'.
+	MisplacedDot                               // a '.' appears other than at either end of the pattern.
+	InvalidCharacter                           // the pattern contains something other than letters, digits and dots.
+	NonStandardPattern                         // the pattern has a replacement, e.g. 'c1k/k=k,1,1', which is ignored.
+)
+
+var patternErrorDescriptions = map[PatternErrorKind]string{
+	EmptyPattern:       `empty pattern`,
+	MultipleDigits:     `multiple digits`,
+	DigitOutsideWord:   `digit outside word boundary`,
+	MisplacedDot:       `misplaced '.'`,
+	InvalidCharacter:   `invalid character`,
+	NonStandardPattern: `unsupported non-standard hyphenation`,
+}
+
+// A PatternError describes a problem found in a pattern string.
+type PatternError struct {
+	Pattern string           // the pattern string.
+	Offset  int              // the rune offset of the problem within the pattern.
+	Line    int              // the line on which the pattern was found, or zero if not read from a file.
+	Kind    PatternErrorKind // the problem found.
+}
+
+func (e *PatternError) String() string {
+	s := fmt.Sprintf("%s at offset %d of pattern '%s'", patternErrorDescriptions[e.Kind], e.Offset, e.Pattern)
+	if e.Line != 0 {
+		s = fmt.Sprintf("line %d: %s", e.Line, s)
+	}
+	return s
+}
+
+// Internal function: whether a rune may appear as a letter in a pattern.  Combining marks are accepted,
+// so that patterns in decomposed form can be used.
+func isPatternLetter(rune int) bool {
+	return unicode.IsLetter(rune) || unicode.Is(unicode.Mn, rune)
+}
+
+// Internal function: whether a rune may appear as a letter in a Hunspell dictionary pattern, where
+// hyphens, dashes and apostrophes are also used, as in the compound patterns '1-1' and '1'1'.
+func isHyphPatternLetter(rune int) bool {
+	return isPatternLetter(rune) || rune == '-' || rune == '–' || isApostrophe(rune)
+}
+
+// Splits a TeX pattern string such as '.hy2p' or '5emnix' into its letters and its digit vector.  The
+// vector holds the value following each letter, preceded by the value before the first letter if the
+// pattern begins with a digit.
+//
+// In Strict mode the first problem found is returned as a *PatternError.  In Lenient mode problems are
+// returned as warnings of type *PatternError instead: only the first of several consecutive digits is
+// used, and anything else unexpected is kept as a letter.  An EmptyPattern is an error in either mode.
+func ParsePattern(s string, mode ParseMode) (letters string, values *vector.IntVector, warnings *vector.Vector, err os.Error) {
+	return parsePattern(s, mode, isPatternLetter)
+}
+
+// Internal function: parses a pattern string as ParsePattern(), accepting the runes for which isLetter
+// returns true as letters.
+func parsePattern(s string, mode ParseMode, isLetter func(int) bool) (letters string, values *vector.IntVector, warnings *vector.Vector, err os.Error) {
+	runes := []int(s)
+	buf := make([]int, 0, len(runes))
+	values = new(vector.IntVector)
+	warnings = new(vector.Vector)
+
+	problem := func(kind PatternErrorKind, offset int) {
+		e := &PatternError{Pattern: s, Offset: offset, Kind: kind}
+		if mode == Strict && err == nil {
+			err = e
+		}
+		warnings.Push(e)
+	}
+
+	// the index of the last letter, after which any digits belong to that letter
+	last := len(runes) - 1
+	for last >= 0 && runes[last] >= '0' && runes[last] <= '9' {
+		last--
+	}
+
+	pending := -1 // the digit seen since the last letter, if any
+	for i, rune := range runes {
+		if rune >= '0' && rune <= '9' {
+			if pending >= 0 {
+				problem(MultipleDigits, i)
+				continue
+			}
+			pending = rune - '0'
+			continue
+		}
+
+		if rune == '.' {
+			switch {
+			case len(buf) == 0:
+				if pending >= 0 {
+					problem(DigitOutsideWord, i-1)
+				}
+			case i == last:
+				if i < len(runes)-1 {
+					problem(DigitOutsideWord, i+1)
+				}
+			default:
+				problem(MisplacedDot, i)
+			}
+		} else if !isLetter(rune) {
+			problem(InvalidCharacter, i)
+		}
+
+		if len(buf) != 0 {
+			// the value following the previous letter
+			values.Push(max(pending, 0))
+		} else if pending >= 0 {
+			// a prefix value
+			values.Push(pending)
+		}
+		buf = buf[0 : len(buf)+1]
+		buf[len(buf)-1] = rune
+		pending = -1
+	}
+
+	// boundary dots on their own don't make a pattern
+	empty := true
+	for _, rune := range buf {
+		if rune != '.' {
+			empty = false
+			break
+		}
+	}
+	if empty {
+		return ``, nil, warnings, &PatternError{Pattern: s, Kind: EmptyPattern}
+	}
+	values.Push(max(pending, 0))
+
+	if err != nil {
+		return ``, nil, warnings, err
+	}
+	return string(buf), values, warnings, nil
+}
+
+// Internal function: returns the larger of two integers.
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// Internal function: returns the smaller of two integers.
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// Adds a TeX pattern string to the trie, as AddPatternString(), checking it with the given mode.  In
+// Strict mode a malformed pattern is not added, and the problem is returned.  Any warnings are returned
+// as elements of type *PatternError.
+func (p *Trie) AddCheckedPatternString(s string, mode ParseMode) (*vector.Vector, os.Error) {
+	return p.addCheckedPattern(s, mode, isPatternLetter)
+}
+
+// Internal function: adds a pattern string as AddCheckedPatternString(), accepting the runes for which
+// isLetter returns true as letters.
+func (p *Trie) addCheckedPattern(s string, mode ParseMode, isLetter func(int) bool) (*vector.Vector, os.Error) {
+	letters, values, warnings, err := parsePattern(s, mode, isLetter)
+	if err != nil {
+		return warnings, err
+	}
+
+	leaf := p.addRunes(strings.NewReader(letters))
+	leaf.value, leaf.valued = values, true
+	return warnings, nil
+}
+
+// Internal function: strips the TeX '\patterns{' command and its braces from a field of a pattern file.
+func trimPatternField(field string) string {
+	if strings.HasPrefix(field, `\patterns`) {
+		field = field[len(`\patterns`):]
+	}
+	if strings.HasPrefix(field, `{`) {
+		field = field[1:]
+	}
+	if strings.HasSuffix(field, `}`) {
+		field = field[0 : len(field)-1]
+	}
+	return field
+}
+
+// Reads whitespace-separated pattern strings from a TeX pattern file, adding each to the trie.  Comments
+// beginning with '%' and any surrounding '\patterns{' and '}' are skipped, as are the exceptions in any
+// '\hyphenation{}' group, such as the one ending Knuth's hyphen.tex.  In Strict mode, loading stops at
+// the first malformed pattern, which is returned with its line number; otherwise all warnings are
+// returned as elements of type *PatternError, and any pattern which can't be repaired, such as a stray
+// '.', is skipped.
+func (p *Trie) AddPatterns(r io.Reader, mode ParseMode) (*vector.Vector, os.Error) {
+	warnings := new(vector.Vector)
+	reader := bufio.NewReader(r)
+	exceptions := false // whether the fields are within a '\hyphenation{' group
+
+	for line := 1; ; line++ {
+		s, err := reader.ReadString('\n')
+		if err != nil && err != os.EOF {
+			return warnings, err
+		}
+
+		if i := strings.Index(s, `%`); i >= 0 {
+			s = s[0:i]
+		}
+		for _, field := range strings.Fields(s) {
+			// hyphenation exceptions aren't patterns, so the whole group is skipped
+			if exceptions {
+				exceptions = !strings.HasSuffix(field, `}`)
+				continue
+			}
+			if strings.HasPrefix(field, `\hyphenation`) {
+				exceptions = !strings.HasSuffix(field, `}`)
+				continue
+			}
+
+			field = trimPatternField(field)
+			if len(field) == 0 {
+				continue
+			}
+
+			w, perr := p.AddCheckedPatternString(field, mode)
+			for i := 0; i < w.Len(); i++ {
+				w.At(i).(*PatternError).Line = line
+			}
+			warnings.AppendVector(w)
+			if perr != nil {
+				e, ok := perr.(*PatternError)
+				if ok {
+					e.Line = line
+				}
+				if mode == Strict || !ok {
+					return warnings, perr
+				}
+
+				// in Lenient mode even an empty pattern is only skipped
+				warnings.Push(e)
+			}
+		}
+
+		if err == os.EOF {
+			break
+		}
+	}
+
+	return warnings, nil
+}
@@ -0,0 +1,161 @@
+/*
+ * pattern_test.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"testing"
+	"container/vector"
+	"strings"
+)
+
+func TestParsePattern(t *testing.T) {
+	letters, values, _, err := ParsePattern(`.ä3b4`, Strict)
+	if err != nil {
+		t.Fatalf("Unexpected error parsing '.ä3b4': %s", err)
+	}
+	if letters != `.äb` {
+		t.Errorf("Expected letters '.äb', got '%s'", letters)
+	}
+	if values.Len() != 3 || values.At(0) != 0 || values.At(1) != 3 || values.At(2) != 4 {
+		t.Errorf("Expected values [0 3 4], got %v", *values)
+	}
+
+	malformed := map[string]*PatternError{
+		`a12b`:   &PatternError{Offset: 2, Kind: MultipleDigits},
+		`1.ab`:   &PatternError{Offset: 0, Kind: DigitOutsideWord},
+		`ab.1`:   &PatternError{Offset: 3, Kind: DigitOutsideWord},
+		`a.b`:    &PatternError{Offset: 1, Kind: MisplacedDot},
+		`a-b`:    &PatternError{Offset: 1, Kind: InvalidCharacter},
+		`123`:    &PatternError{Offset: 0, Kind: EmptyPattern},
+		`.`:      &PatternError{Offset: 0, Kind: EmptyPattern},
+		`..`:     &PatternError{Offset: 0, Kind: EmptyPattern},
+		`ü1ö23ß`: &PatternError{Offset: 4, Kind: MultipleDigits},
+	}
+	for s, expected := range malformed {
+		_, _, _, err := ParsePattern(s, Strict)
+		e, ok := err.(*PatternError)
+		if !ok {
+			t.Errorf("Expected a *PatternError for '%s', got %v", s, err)
+			continue
+		}
+		if e.Kind != expected.Kind || e.Offset != expected.Offset {
+			t.Errorf("Expected '%s' for '%s', got '%s'", patternErrorDescriptions[expected.Kind], s, e)
+		}
+	}
+
+	// lenient mode keeps the first of several digits
+	letters, values, warnings, err := ParsePattern(`a12b`, Lenient)
+	if err != nil {
+		t.Fatalf("Unexpected error parsing 'a12b' leniently: %s", err)
+	}
+	if letters != `ab` || values.Len() != 2 || values.At(0) != 1 {
+		t.Errorf("Expected 'ab' with values [1 0], got '%s' with %v", letters, *values)
+	}
+	if warnings.Len() != 1 {
+		t.Errorf("Expected one warning, got %d", warnings.Len())
+	}
+}
+
+func TestAddPatterns(t *testing.T) {
+	file := "% a comment\n\\patterns{\n.hy3p he2n\nhen5at a12b }\n"
+
+	trie := NewTrie()
+	warnings, err := trie.AddPatterns(strings.NewReader(file), Lenient)
+	if err != nil {
+		t.Fatalf("Unexpected error loading patterns: %s", err)
+	}
+	if warnings.Len() != 1 || warnings.At(0).(*PatternError).Line != 4 {
+		t.Errorf("Expected one warning, on line 4, got %v", *warnings)
+	}
+	if trie.Members().Len() != 4 {
+		t.Errorf("Expected 4 patterns, got %v", *trie.Members())
+	}
+	checkValues(trie, `henat`, &vector.IntVector{0, 0, 5, 0, 0}, t)
+
+	trie = NewTrie()
+	_, err = trie.AddPatterns(strings.NewReader(file), Strict)
+	if e, ok := err.(*PatternError); !ok || e.Line != 4 || e.Pattern != `a12b` {
+		t.Errorf("Expected an error for 'a12b' on line 4, got %v", err)
+	}
+}
+
+func TestAddPatternsSkipsEmpty(t *testing.T) {
+	file := "1ba\n. \n3\nab1c\n"
+
+	trie := NewTrie()
+	warnings, err := trie.AddPatterns(strings.NewReader(file), Lenient)
+	if err != nil {
+		t.Fatalf("Unexpected error loading patterns leniently: %s", err)
+	}
+	if warnings.Len() != 2 {
+		t.Fatalf("Expected 2 warnings, got %v", *warnings)
+	}
+	for i, line := range []int{2, 3} {
+		if e := warnings.At(i).(*PatternError); e.Kind != EmptyPattern || e.Line != line {
+			t.Errorf("Expected an empty pattern on line %d, got '%s'", line, e)
+		}
+	}
+	if !trie.Contains(`ba`) || !trie.Contains(`abc`) {
+		t.Errorf("Expected the patterns either side of the empty ones, got %v", *trie.Members())
+	}
+
+	trie = NewTrie()
+	_, err = trie.AddPatterns(strings.NewReader(file), Strict)
+	if e, ok := err.(*PatternError); !ok || e.Kind != EmptyPattern || e.Line != 2 {
+		t.Errorf("Expected an empty pattern error on line 2, got %v", err)
+	}
+}
+
+func TestAddPatternsWithExceptions(t *testing.T) {
+	file := "\\patterns{\n.hy3p he2n\n}\n\\hyphenation{as-so-ciate\nproj-ect ta-ble}\nhen5at\n"
+
+	for _, mode := range []ParseMode{Strict, Lenient} {
+		trie := NewTrie()
+		warnings, err := trie.AddPatterns(strings.NewReader(file), mode)
+		if err != nil {
+			t.Fatalf("Unexpected error loading patterns with exceptions: %s", err)
+		}
+		if warnings.Len() != 0 {
+			t.Errorf("Expected no warnings for the exceptions, got %v", *warnings)
+		}
+		if trie.Members().Len() != 3 {
+			t.Errorf("Expected only the 3 patterns, got %v", *trie.Members())
+		}
+	}
+}
@@ -0,0 +1,127 @@
+/*
+ * rank.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+// Returns the number of member strings in the trie.  Leaf counts are maintained as strings are added
+// and removed, so this does not walk the trie.
+func (p *Trie) Len() int {
+	return p.count
+}
+
+// Internal function: returns the number of member strings below the children before the given index in
+// rune order.
+func (p *Trie) countBefore(index int) (sum int) {
+	for k := index; k > 0; k -= k & -k {
+		sum += p.counts[k]
+	}
+	return
+}
+
+// Internal function: finds the child below which the i'th member string under the children lies, in
+// rune order.  Returns the index of the child, and the index of the string among those below it.
+func (p *Trie) childAt(i int) (int, int) {
+	step := 1
+	for 2*step <= len(p.runes) {
+		step *= 2
+	}
+
+	index := 0
+	for ; step > 0; step /= 2 {
+		if next := index + step; next <= len(p.runes) && p.counts[next] <= i {
+			index = next
+			i -= p.counts[next]
+		}
+	}
+	return index, i
+}
+
+// Returns the number of member strings which sort before s in rune order.  The result is the same
+// whether or not s is itself a member.  Each node keeps a running count of the strings below its
+// children, so this takes time proportional to the length of s and the logarithm of the number of
+// children at each node it passes.
+func (p *Trie) Rank(s string) (rank int) {
+	for _, rune := range s {
+		if p.leaf {
+			// this node's string is a proper prefix of s
+			rank++
+		}
+
+		i := p.runeIndex(rune)
+		rank += p.countBefore(i)
+		if i == len(p.runes) || p.runes[i] != rune {
+			return
+		}
+		p = p.children[rune]
+	}
+	return
+}
+
+// Returns the member string at index i in rune order, along with its value.  The boolean result is
+// false if i is out of range.  This takes time proportional to the length of the string found, and the
+// logarithm of the number of children at each node it passes.
+func (p *Trie) Select(i int) (string, interface{}, bool) {
+	if i < 0 || i >= p.count {
+		return "", nil, false
+	}
+
+	key := new(keyBuffer)
+	for {
+		if p.leaf {
+			if i == 0 {
+				return string(key.bytes), p.value, true
+			}
+			i--
+		}
+
+		index, rest := p.childAt(i)
+		rune := p.runes[index]
+		key.push(rune)
+		p, i = p.children[rune], rest
+	}
+	panic("unreachable")
+}
+
+// Returns the number of member strings beginning with the given prefix, including the prefix itself.
+func (p *Trie) CountPrefix(prefix string) int {
+	n := p.node(prefix)
+	if n == nil {
+		return 0
+	}
+	return n.count
+}
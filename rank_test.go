@@ -0,0 +1,154 @@
+/*
+ * rank_test.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"testing"
+)
+
+func TestRankAndSelect(t *testing.T) {
+	trie := NewTrie()
+	keys := []string{`inn`, `te`, `tea`, `ten`, `to`, `té`}
+	for i := len(keys) - 1; i >= 0; i-- {
+		trie.AddValue(keys[i], i)
+	}
+	trie.AddString(`tea`)
+
+	if trie.Len() != len(keys) {
+		t.Fatalf("Expected Len() to be %d, got %d", len(keys), trie.Len())
+	}
+
+	for i, key := range keys {
+		if rank := trie.Rank(key); rank != i {
+			t.Errorf("Expected Rank(%s) to be %d, got %d", key, i, rank)
+		}
+		if s, value, ok := trie.Select(i); !ok || s != key || value != i {
+			t.Errorf("Expected Select(%d) to be %s/%d, got %s/%v/%v", i, key, i, s, value, ok)
+		}
+	}
+
+	if rank := trie.Rank(`tee`); rank != 3 {
+		t.Errorf("Expected Rank(tee) to be 3, got %d", rank)
+	}
+	if rank := trie.Rank(`zzz`); rank != len(keys) {
+		t.Errorf("Expected Rank(zzz) to be %d, got %d", len(keys), rank)
+	}
+	if _, _, ok := trie.Select(len(keys)); ok {
+		t.Error("Expected Select() past the end to fail")
+	}
+
+	if n := trie.CountPrefix(`te`); n != 3 {
+		t.Errorf("Expected CountPrefix(te) to be 3, got %d", n)
+	}
+	if n := trie.CountPrefix(`t`); n != 5 {
+		t.Errorf("Expected CountPrefix(t) to be 5, got %d", n)
+	}
+	if n := trie.CountPrefix(`x`); n != 0 {
+		t.Errorf("Expected CountPrefix(x) to be 0, got %d", n)
+	}
+
+	// counts must follow removals, including of strings which aren't present
+	trie.Remove(`te`)
+	trie.Remove(`tex`)
+	trie.Remove(`t`)
+	if trie.Len() != len(keys)-1 {
+		t.Errorf("Expected Len() to be %d after removal, got %d", len(keys)-1, trie.Len())
+	}
+	if n := trie.CountPrefix(`te`); n != 2 {
+		t.Errorf("Expected CountPrefix(te) to be 2 after removal, got %d", n)
+	}
+	if s, _, _ := trie.Select(1); s != `tea` {
+		t.Errorf("Expected Select(1) to be tea after removal, got %s", s)
+	}
+}
+
+func TestRankAfterPruning(t *testing.T) {
+	// removing a string mustn't prune a shorter member which it extends
+	trie := NewTrie()
+	trie.AddString(`a`)
+	trie.AddString(`ab`)
+	trie.Remove(`ab`)
+	if !trie.Contains(`a`) || trie.Len() != 1 || trie.CountPrefix(`a`) != 1 {
+		t.Errorf("Expected 'a' to remain as the only member, got %v with Len() %d", *trie.Members(), trie.Len())
+	}
+	if s, _, ok := trie.Select(0); !ok || s != `a` {
+		t.Errorf("Expected Select(0) to be 'a', got '%s'", s)
+	}
+}
+
+func TestRankWideNode(t *testing.T) {
+	// a node with many children, as for CJK text
+	trie := NewTrie()
+	for rune := 0x4e00 + 999; rune >= 0x4e00; rune-- {
+		trie.AddValue(string(rune)+`x`, rune)
+		if rune%3 == 0 {
+			trie.AddValue(string(rune), rune)
+		}
+	}
+
+	members := trie.Members()
+	if trie.Len() != members.Len() {
+		t.Fatalf("Expected Len() to be %d, got %d", members.Len(), trie.Len())
+	}
+	for i := 0; i < members.Len(); i++ {
+		if s, _, _ := trie.Select(i); s != members.At(i) {
+			t.Fatalf("Expected Select(%d) to be '%s', got '%s'", i, members.At(i), s)
+		}
+		if rank := trie.Rank(members.At(i)); rank != i {
+			t.Fatalf("Expected Rank('%s') to be %d, got %d", members.At(i), i, rank)
+		}
+	}
+
+	// counts must follow removals from the middle of the node
+	for rune := 0x4e00 + 100; rune < 0x4e00+200; rune++ {
+		trie.Remove(string(rune) + `x`)
+	}
+	members = trie.Members()
+	if trie.Len() != members.Len() {
+		t.Fatalf("Expected Len() to be %d after removal, got %d", members.Len(), trie.Len())
+	}
+	for i := 0; i < members.Len(); i++ {
+		if s, _, _ := trie.Select(i); s != members.At(i) {
+			t.Fatalf("Expected Select(%d) to be '%s' after removal, got '%s'", i, members.At(i), s)
+		}
+		if rank := trie.Rank(members.At(i)); rank != i {
+			t.Fatalf("Expected Rank('%s') to be %d after removal, got %d", members.At(i), i, rank)
+		}
+	}
+}
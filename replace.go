@@ -0,0 +1,175 @@
+/*
+ * replace.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"bytes"
+	"container/vector"
+	"sort"
+	"strings"
+	"unicode"
+	"utf8"
+)
+
+// Flags controlling how a Replacer matches.
+const (
+	WholeWords = 1 << iota // only match where neither end of the match is beside a letter or digit.
+	IgnoreCase             // match regardless of case.
+)
+
+// A Replacer finds and replaces the member strings of a Trie within a text, choosing leftmost-longest,
+// non-overlapping matches in the manner of strings.Replacer.  It is safe for concurrent use.
+type Replacer struct {
+	matcher *Matcher
+	flags   int
+}
+
+// The value stored against each case-folded key when compiling a case-insensitive Replacer.
+type foldedEntry struct {
+	key   string
+	value interface{}
+}
+
+// Internal function: case-folds a rune.
+func foldRune(rune int) int {
+	return unicode.ToLower(rune)
+}
+
+// Creates and returns a new Replacer for the member strings of the trie and their values.  The flags are
+// a combination of WholeWords and IgnoreCase.  When ignoring case, any keys differing only in case are
+// treated as one, and the first in order is used.
+func (p *Trie) NewReplacer(flags int) *Replacer {
+	if flags&IgnoreCase == 0 {
+		return &Replacer{p.Compile(), flags}
+	}
+
+	folded := NewTrie()
+	members := p.Members()
+	for i := 0; i < members.Len(); i++ {
+		key := members.At(i)
+		foldedKey := strings.Map(foldRune, key)
+		if !folded.Contains(foldedKey) {
+			value, _ := p.GetValue(key)
+			folded.AddValue(foldedKey, &foldedEntry{key, value})
+		}
+	}
+	return &Replacer{folded.Compile(), flags}
+}
+
+// Internal type used to sort a vector of *Match by start offset, and then with the longest first.
+type matchList struct {
+	*vector.Vector
+}
+
+func (l matchList) Less(i, j int) bool {
+	a, b := l.At(i).(*Match), l.At(j).(*Match)
+	return a.Start < b.Start || (a.Start == b.Start && a.End > b.End)
+}
+
+// Internal function: whether a rune forms part of a word, for the WholeWords flag.
+func isWordCharacter(rune int) bool {
+	return unicode.IsLetter(rune) || unicode.IsDigit(rune) || unicode.Is(unicode.Mn, rune)
+}
+
+// Internal function: whether a match lies at word boundaries within s.
+func isWholeWord(s string, m *Match) bool {
+	if m.Start > 0 {
+		i := m.Start - 1
+		for i > 0 && !utf8.RuneStart(s[i]) {
+			i--
+		}
+		rune, _ := utf8.DecodeRuneInString(s[i:m.Start])
+		if isWordCharacter(rune) {
+			return false
+		}
+	}
+	if m.End < len(s) {
+		rune, _ := utf8.DecodeRuneInString(s[m.End:])
+		if isWordCharacter(rune) {
+			return false
+		}
+	}
+	return true
+}
+
+// Finds the leftmost-longest, non-overlapping occurrences of member strings within s.  The elements of
+// the returned vector are of type *Match, in order; when ignoring case, each Key is the member string
+// matched rather than the text.
+func (r *Replacer) FindAll(s string) *vector.Vector {
+	var all *vector.Vector
+	if r.flags&IgnoreCase != 0 {
+		all = r.matcher.findAll(s, foldRune)
+	} else {
+		all = r.matcher.findAll(s, nil)
+	}
+	sort.Sort(matchList{all})
+
+	matches := new(vector.Vector)
+	end := 0
+	for i := 0; i < all.Len(); i++ {
+		m := all.At(i).(*Match)
+		if m.Start < end || (r.flags&WholeWords != 0 && !isWholeWord(s, m)) {
+			continue
+		}
+		if entry, ok := m.Value.(*foldedEntry); ok {
+			m.Key, m.Value = entry.key, entry.value
+		}
+		matches.Push(m)
+		end = m.End
+	}
+	return matches
+}
+
+// Returns a copy of s with each match, as found by FindAll(), replaced by the result of calling f with
+// the member string matched and its value.
+func (r *Replacer) ReplaceAll(s string, f func(key string, value interface{}) string) string {
+	buf := new(bytes.Buffer)
+	matches := r.FindAll(s)
+
+	last := 0
+	for i := 0; i < matches.Len(); i++ {
+		m := matches.At(i).(*Match)
+		buf.WriteString(s[last:m.Start])
+		buf.WriteString(f(m.Key, m.Value))
+		last = m.End
+	}
+	buf.WriteString(s[last:])
+
+	return buf.String()
+}
@@ -0,0 +1,84 @@
+/*
+ * replace_test.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"testing"
+	"strings"
+)
+
+func TestReplacer(t *testing.T) {
+	trie := NewTrie()
+	trie.AddValue(`New York`, `NY`)
+	trie.AddValue(`New York City`, `NYC`)
+	trie.AddValue(`York`, `YK`)
+	trie.AddValue(`ork`, `ORK`)
+
+	replace := func(key string, value interface{}) string {
+		return `[` + value.(string) + `]`
+	}
+
+	// leftmost-longest, without overlaps
+	r := trie.NewReplacer(0)
+	s := r.ReplaceAll(`New York City and New York, not York or Yorkshire`, replace)
+	if s != `[NYC] and [NY], not [YK] or [YK]shire` {
+		t.Errorf("Unexpected replacement '%s'", s)
+	}
+
+	r = trie.NewReplacer(WholeWords)
+	s = r.ReplaceAll(`New York City and New York, not York or Yorkshire`, replace)
+	if s != `[NYC] and [NY], not [YK] or Yorkshire` {
+		t.Errorf("Unexpected whole-word replacement '%s'", s)
+	}
+
+	r = trie.NewReplacer(WholeWords | IgnoreCase)
+	matches := r.FindAll(`NEW YORK city and new york`)
+	if matches.Len() != 2 {
+		t.Fatalf("Expected 2 case-insensitive matches, got %d", matches.Len())
+	}
+	if m := matches.At(0).(*Match); m.Key != `New York City` || m.Value != `NYC` || m.End != 13 {
+		t.Errorf("Expected to match 'New York City' at 0-13, got %v", *m)
+	}
+	s = r.ReplaceAll(`NEW YORK city and new york`, func(key string, value interface{}) string {
+		return strings.ToUpper(key)
+	})
+	if s != `NEW YORK CITY and NEW YORK` {
+		t.Errorf("Unexpected case-insensitive replacement '%s'", s)
+	}
+}
@@ -0,0 +1,173 @@
+/*
+ * segment.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"container/vector"
+	"math"
+	"utf8"
+)
+
+// A Token is one word found by a Segmenter.
+type Token struct {
+	Start int    // the byte offset of the start of the token.
+	End   int    // the byte offset just after the end of the token.
+	Text  string // the text of the token.
+	Known bool   // whether the token is a word in the lexicon, rather than a single unknown rune.
+}
+
+// A Segmenter splits text written without spaces, such as Chinese, Japanese or Thai, into the words of
+// a lexicon.  The value stored with each word in the lexicon may be its frequency, as an int or float64;
+// words with any other value are given a frequency of 1.  The empty string is never a word.
+type Segmenter struct {
+	lexicon *Trie
+	total   float64 // the sum of the frequencies of every word in the lexicon.
+}
+
+// Internal function: returns the frequency represented by a value stored in the lexicon.
+func frequency(value interface{}) float64 {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case float64:
+		return v
+	}
+	return 1
+}
+
+// Creates and returns a new Segmenter using the given lexicon.  The lexicon shouldn't be changed while
+// the Segmenter is in use.
+func NewSegmenter(lexicon *Trie) *Segmenter {
+	s := &Segmenter{lexicon, 0}
+	lexicon.walk(``, func(key string, leaf *Trie) {
+		if len(key) != 0 {
+			s.total += frequency(leaf.value)
+		}
+	})
+	return s
+}
+
+// Internal function: returns a token for the single rune at the start of text[start:].
+func unknownToken(text string, start int) *Token {
+	_, size := utf8.DecodeRuneInString(text[start:])
+	return &Token{start, start + size, text[start : start+size], false}
+}
+
+// Splits text by forward maximum matching: the longest word in the lexicon is taken at each position,
+// or a single rune if no word starts there.  The elements of the returned vector are of type *Token.
+func (s *Segmenter) MaximumMatch(text string) *vector.Vector {
+	tokens := new(vector.Vector)
+	for start := 0; start < len(text); {
+		words := s.lexicon.AllSubstrings(text[start:])
+
+		var t *Token
+		if words.Len() == 0 || len(words.Last()) == 0 {
+			t = unknownToken(text, start)
+		} else {
+			word := words.Last()
+			t = &Token{start, start + len(word), word, true}
+		}
+		tokens.Push(t)
+		start = t.End
+	}
+	return tokens
+}
+
+// Splits text into the most probable sequence of words, using the Viterbi algorithm over the lattice of
+// every word in the lexicon found within it.  Each word's probability is its frequency divided by the
+// total frequency of the lexicon; a rune not starting any word becomes a token by itself, with a tenth
+// of the probability of a word with a frequency of 1.  The elements of the returned vector are of
+// type *Token.
+func (s *Segmenter) Viterbi(text string) *vector.Vector {
+	total := s.total
+	if total < 1 {
+		total = 1
+	}
+	unknown := math.Log(0.1 / total)
+
+	// best[i] is the log probability of the best path to byte offset i, and last[i] the token ending it
+	best := make([]float64, len(text)+1)
+	last := make([]*Token, len(text)+1)
+	reached := make([]bool, len(text)+1)
+	reached[0] = true
+
+	for start := 0; start < len(text); start++ {
+		if !reached[start] {
+			continue
+		}
+
+		words, values := s.lexicon.AllSubstringsAndValues(text[start:])
+		candidates := new(vector.Vector)
+		scores := new(vector.Vector)
+		for i := 0; i < words.Len(); i++ {
+			word := words.At(i)
+			if len(word) == 0 {
+				continue
+			}
+			candidates.Push(&Token{start, start + len(word), word, true})
+			scores.Push(math.Log(frequency(values.At(i)) / total))
+		}
+		if candidates.Len() == 0 {
+			candidates.Push(unknownToken(text, start))
+			scores.Push(unknown)
+		}
+
+		for i := 0; i < candidates.Len(); i++ {
+			t := candidates.At(i).(*Token)
+			score := best[start] + scores.At(i).(float64)
+			if !reached[t.End] || score > best[t.End] {
+				best[t.End] = score
+				last[t.End] = t
+				reached[t.End] = true
+			}
+		}
+	}
+
+	// follow the tokens back from the end of the text
+	reversed := new(vector.Vector)
+	for end := len(text); end > 0; end = last[end].Start {
+		reversed.Push(last[end])
+	}
+
+	tokens := new(vector.Vector)
+	for i := reversed.Len() - 1; i >= 0; i-- {
+		tokens.Push(reversed.At(i))
+	}
+	return tokens
+}
@@ -0,0 +1,84 @@
+/*
+ * segment_test.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"testing"
+	"container/vector"
+)
+
+func checkTokens(tokens *vector.Vector, expected *vector.StringVector, t *testing.T) {
+	texts := new(vector.StringVector)
+	for i := 0; i < tokens.Len(); i++ {
+		texts.Push(tokens.At(i).(*Token).Text)
+	}
+	if texts.Len() != expected.Len() {
+		t.Errorf("Expected tokens %v, got %v", *expected, *texts)
+		return
+	}
+	for i := 0; i < texts.Len(); i++ {
+		if texts.At(i) != expected.At(i) {
+			t.Errorf("Expected tokens %v, got %v", *expected, *texts)
+			return
+		}
+	}
+}
+
+func TestSegmenter(t *testing.T) {
+	lexicon := NewTrie()
+	lexicon.AddValue(`研究`, 10)
+	lexicon.AddValue(`研究生`, 5)
+	lexicon.AddValue(`生命`, 10)
+	lexicon.AddValue(`起源`, 10.0)
+	lexicon.AddValue(`生`, 2)
+	lexicon.AddValue(`命`, 2)
+
+	s := NewSegmenter(lexicon)
+	text := `研究生命的起源`
+
+	// maximum matching greedily takes the longest word
+	tokens := s.MaximumMatch(text)
+	checkTokens(tokens, &vector.StringVector{`研究生`, `命`, `的`, `起源`}, t)
+	if unknown := tokens.At(2).(*Token); unknown.Known || unknown.Start != 12 || unknown.End != 15 {
+		t.Errorf("Expected an unknown token at 12-15, got %v", *unknown)
+	}
+
+	// Viterbi finds the more probable path
+	checkTokens(s.Viterbi(text), &vector.StringVector{`研究`, `生命`, `的`, `起源`}, t)
+}
@@ -0,0 +1,207 @@
+/*
+ * setops.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+// A MergeFunc chooses the value of a string present in both tries combined by a set operation, given
+// the values it has in the left and right tries.
+type MergeFunc func(key string, left, right interface{}) interface{}
+
+// Internal function: returns a deep copy of the trie below this node.
+func (p *Trie) copy() *Trie {
+	n := NewTrie()
+	n.leaf, n.value, n.valued, n.count = p.leaf, p.value, p.valued, p.count
+	for _, rune := range p.runes {
+		n.appendChild(rune, p.children[rune].copy())
+	}
+	n.rebuildCounts()
+	return n
+}
+
+// Internal function: returns a new node for a member string present in the left and/or right tries,
+// taking its value from whichever has it, or by calling merge if both do.
+func mergedNode(left, right *Trie, key *keyBuffer, merge MergeFunc) *Trie {
+	n := NewTrie()
+	switch {
+	case left != nil && left.leaf && right != nil && right.leaf:
+		n.leaf, n.valued = true, left.valued || right.valued
+		if merge != nil {
+			n.value = merge(string(key.bytes), left.value, right.value)
+		} else if left.valued || !right.valued {
+			n.value = left.value
+		} else {
+			n.value = right.value
+		}
+	case left != nil && left.leaf:
+		n.leaf, n.value, n.valued = true, left.value, left.valued
+	case right != nil && right.leaf:
+		n.leaf, n.value, n.valued = true, right.value, right.valued
+	}
+	if n.leaf {
+		n.count = 1
+	}
+	return n
+}
+
+// Internal function: adds a child to a node built by a set operation, unless it has no members.  Children
+// must be added in rune order, and rebuildCounts() called once they all have been.
+func (p *Trie) addChild(rune int, child *Trie) {
+	if child.count != 0 {
+		p.appendChild(rune, child)
+		p.count += child.count
+	}
+}
+
+// Internal function: calls f with each rune having a child in either of two nodes, in ascending order,
+// along with the child in each, which is nil if that node has none.
+func eachChild(left, right *Trie, f func(rune int, left, right *Trie)) {
+	i, j := 0, 0
+	for i < len(left.runes) || j < len(right.runes) {
+		switch {
+		case j == len(right.runes) || (i < len(left.runes) && left.runes[i] < right.runes[j]):
+			f(left.runes[i], left.children[left.runes[i]], nil)
+			i++
+		case i == len(left.runes) || right.runes[j] < left.runes[i]:
+			f(right.runes[j], nil, right.children[right.runes[j]])
+			j++
+		default:
+			f(left.runes[i], left.children[left.runes[i]], right.children[right.runes[j]])
+			i++
+			j++
+		}
+	}
+}
+
+// Internal function: the union of two nodes, either of which may be nil.
+func union(left, right *Trie, key *keyBuffer, merge MergeFunc) *Trie {
+	if left == nil {
+		return right.copy()
+	}
+	if right == nil {
+		return left.copy()
+	}
+
+	n := mergedNode(left, right, key, merge)
+	eachChild(left, right, func(rune int, l, r *Trie) {
+		size := key.push(rune)
+		n.addChild(rune, union(l, r, key, merge))
+		key.pop(size)
+	})
+	n.rebuildCounts()
+	return n
+}
+
+// Internal function: the intersection of two nodes.
+func intersection(left, right *Trie, key *keyBuffer, merge MergeFunc) *Trie {
+	n := mergedNode(left, right, key, merge)
+	if !left.leaf || !right.leaf {
+		n.leaf, n.value, n.valued, n.count = false, nil, false, 0
+	}
+
+	for _, rune := range left.runes {
+		if other, ok := right.children[rune]; ok {
+			size := key.push(rune)
+			n.addChild(rune, intersection(left.children[rune], other, key, merge))
+			key.pop(size)
+		}
+	}
+	n.rebuildCounts()
+	return n
+}
+
+// Internal function: the members of the left node which aren't members of the right, which may be nil.
+func difference(left, right *Trie) *Trie {
+	if right == nil {
+		return left.copy()
+	}
+
+	n := NewTrie()
+	if left.leaf && !right.leaf {
+		n.leaf, n.value, n.valued, n.count = true, left.value, left.valued, 1
+	}
+	for _, rune := range left.runes {
+		n.addChild(rune, difference(left.children[rune], right.children[rune]))
+	}
+	n.rebuildCounts()
+	return n
+}
+
+// Internal function: the members of exactly one of two nodes, either of which may be nil.
+func symmetricDifference(left, right *Trie) *Trie {
+	if left == nil {
+		return right.copy()
+	}
+	if right == nil {
+		return left.copy()
+	}
+
+	n := NewTrie()
+	if left.leaf != right.leaf {
+		n = mergedNode(left, right, nil, nil)
+	}
+	eachChild(left, right, func(rune int, l, r *Trie) {
+		n.addChild(rune, symmetricDifference(l, r))
+	})
+	n.rebuildCounts()
+	return n
+}
+
+// Returns a new trie containing the member strings of both this trie and the other.  The value of a
+// string in both is the result of calling merge, or its value in this trie if merge is nil, unless only
+// the other trie stored a value for it.  Values are shared with the original tries, rather than copied.
+func (p *Trie) Union(other *Trie, merge MergeFunc) *Trie {
+	return union(p, other, new(keyBuffer), merge)
+}
+
+// Returns a new trie containing the member strings present in both this trie and the other, with values
+// chosen as by Union().
+func (p *Trie) Intersection(other *Trie, merge MergeFunc) *Trie {
+	return intersection(p, other, new(keyBuffer), merge)
+}
+
+// Returns a new trie containing the member strings of this trie which aren't in the other, with their
+// values from this trie.
+func (p *Trie) Difference(other *Trie) *Trie {
+	return difference(p, other)
+}
+
+// Returns a new trie containing the member strings present in exactly one of this trie and the other,
+// with their values from that trie.
+func (p *Trie) SymmetricDifference(other *Trie) *Trie {
+	return symmetricDifference(p, other)
+}
@@ -0,0 +1,136 @@
+/*
+ * setops_test.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"testing"
+	"container/vector"
+)
+
+func checkMembers(name string, trie *Trie, expected []string, t *testing.T) {
+	members := trie.Members()
+	if members.Len() != len(expected) || trie.Len() != len(expected) {
+		t.Errorf("Expected %s to be %v, got %v (%d members)", name, expected, *members, trie.Len())
+		return
+	}
+	for i, s := range expected {
+		if members.At(i) != s {
+			t.Errorf("Expected %s to be %v, got %v", name, expected, *members)
+			return
+		}
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	allow := NewTrie()
+	allow.AddValue(`tea`, 1)
+	allow.AddValue(`ten`, 2)
+	allow.AddValue(`to`, 3)
+	deny := NewTrie()
+	deny.AddValue(`te`, 10)
+	deny.AddValue(`ten`, 20)
+	deny.AddValue(`inn`, 30)
+
+	keys := new(vector.StringVector)
+	sum := func(key string, left, right interface{}) interface{} {
+		keys.Push(key)
+		return left.(int) + right.(int)
+	}
+
+	union := allow.Union(deny, sum)
+	checkMembers("union", union, []string{`inn`, `te`, `tea`, `ten`, `to`}, t)
+	if v, _ := union.GetValue(`ten`); v != 22 {
+		t.Errorf("Expected 'ten' to have merged value 22, got %v", v)
+	}
+	if v, _ := union.GetValue(`te`); v != 10 {
+		t.Errorf("Expected 'te' to have value 10, got %v", v)
+	}
+	if keys.Len() != 1 || keys.At(0) != `ten` {
+		t.Errorf("Expected merge to be called only for 'ten', got %v", *keys)
+	}
+
+	intersection := allow.Intersection(deny, nil)
+	checkMembers("intersection", intersection, []string{`ten`}, t)
+	if v, _ := intersection.GetValue(`ten`); v != 2 {
+		t.Errorf("Expected 'ten' to keep its left value 2, got %v", v)
+	}
+	if intersection.Size() != 3 {
+		t.Errorf("Expected the intersection to have 3 nodes, got %d", intersection.Size())
+	}
+
+	checkMembers("difference", allow.Difference(deny), []string{`tea`, `to`}, t)
+	checkMembers("symmetric difference", allow.SymmetricDifference(deny), []string{`inn`, `te`, `tea`, `to`}, t)
+
+	// the originals are unchanged, and share no nodes with the results
+	union.Remove(`tea`)
+	checkMembers("allow", allow, []string{`tea`, `ten`, `to`}, t)
+	checkMembers("deny", deny, []string{`inn`, `te`, `ten`}, t)
+	if allow.Intersection(NewTrie(), nil).Size() != 0 {
+		t.Error("Expected the intersection with an empty trie to have no nodes")
+	}
+}
+
+func TestSetOperationValues(t *testing.T) {
+	plain := NewTrie()
+	plain.AddString(`plain`)
+	plain.AddValue(`empty`, nil)
+	plain.AddString(`only`)
+	valued := NewTrie()
+	valued.AddValue(`plain`, 3)
+
+	// a union takes whichever value was stored
+	union := plain.Union(valued, nil)
+	if v, _ := union.GetValue(`plain`); !union.HasValue(`plain`) || v != 3 {
+		t.Errorf("Expected the union to take the other trie's value 3, got %v", v)
+	}
+	if !union.HasValue(`empty`) || union.HasValue(`only`) {
+		t.Error("Expected the union to keep values only for members which had them")
+	}
+
+	intersection := union.Intersection(valued, nil)
+	if !intersection.HasValue(`plain`) || intersection.HasValue(`empty`) {
+		t.Error("Expected the intersection to keep values only for its members")
+	}
+	if difference := union.Difference(valued); !difference.HasValue(`empty`) || difference.HasValue(`only`) {
+		t.Error("Expected the difference to keep the values of its members")
+	}
+	if copied := plain.SymmetricDifference(NewTrie()); !copied.HasValue(`empty`) || copied.HasValue(`plain`) {
+		t.Error("Expected a copied trie to keep its values")
+	}
+}
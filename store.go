@@ -0,0 +1,100 @@
+/*
+ * store.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"strings"
+)
+
+// Sets the value associated with a string, adding it to the trie if necessary.  Returns the previous
+// value, and whether the string was already a member.  A member added by AddString() has no previous
+// value, so previous is nil for it; use HasValue() to tell whether a member had one.
+func (p *Trie) Put(s string, v interface{}) (previous interface{}, existed bool) {
+	before := p.count
+	leaf := p.addRunes(strings.NewReader(s))
+	previous, existed = leaf.value, p.count == before
+	leaf.value, leaf.valued = v, true
+	return
+}
+
+// Removes a string from the trie.  Returns the value it had, and whether it was a member.
+func (p *Trie) Delete(s string) (interface{}, bool) {
+	leaf := p.includes(strings.NewReader(s))
+	if leaf == nil {
+		return nil, false
+	}
+
+	v := leaf.value
+	p.removeRunes(strings.NewReader(s))
+	return v, true
+}
+
+// Returns the value associated with a string if it is a member.  Otherwise, adds the string with the
+// given value, and returns that.  The boolean result is true if the value was loaded, false if stored.
+// A member added by AddString() is loaded with a nil value, and keeps having none.
+func (p *Trie) LoadOrStore(s string, v interface{}) (actual interface{}, loaded bool) {
+	before := p.count
+	leaf := p.addRunes(strings.NewReader(s))
+	if p.count == before {
+		return leaf.value, true
+	}
+	leaf.value, leaf.valued = v, true
+	return v, false
+}
+
+// Replaces the value associated with a string with a new one, but only if the string has a value, and
+// it is equal to old.  Returns true if the value was replaced.  As with the == operator, this
+// panics if old and the stored value have the same type, and that type isn't comparable.
+func (p *Trie) CompareAndSwap(s string, old, new interface{}) bool {
+	leaf := p.includes(strings.NewReader(s))
+	if leaf == nil || !leaf.valued || leaf.value != old {
+		return false
+	}
+
+	leaf.value = new
+	return true
+}
+
+// Sets the value associated with a string to the result of calling f with its current value, and
+// whether it has one, adding it to the trie if necessary.  Returns the new value.
+func (p *Trie) Update(s string, f func(old interface{}, ok bool) interface{}) interface{} {
+	leaf := p.addRunes(strings.NewReader(s))
+	leaf.value, leaf.valued = f(leaf.value, leaf.valued), true
+	return leaf.value
+}
@@ -0,0 +1,147 @@
+/*
+ * store_test.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"testing"
+)
+
+func TestStore(t *testing.T) {
+	trie := NewTrie()
+
+	if prev, existed := trie.Put(`sku`, 1); existed || prev != nil {
+		t.Errorf("Expected Put() of a new string to return <nil>/false, got %v/%v", prev, existed)
+	}
+	if prev, existed := trie.Put(`sku`, 2); !existed || prev != 1 {
+		t.Errorf("Expected Put() to return 1/true, got %v/%v", prev, existed)
+	}
+
+	// a member added without a value exists, but has no previous value
+	trie.AddString(`skip`)
+	if prev, existed := trie.Put(`skip`, 3); !existed || prev != nil {
+		t.Errorf("Expected Put() to return <nil>/true, got %v/%v", prev, existed)
+	}
+
+	if actual, loaded := trie.LoadOrStore(`sku`, 4); !loaded || actual != 2 {
+		t.Errorf("Expected LoadOrStore() to load 2, got %v/%v", actual, loaded)
+	}
+	if actual, loaded := trie.LoadOrStore(`sky`, 5); loaded || actual != 5 {
+		t.Errorf("Expected LoadOrStore() to store 5, got %v/%v", actual, loaded)
+	}
+
+	if trie.CompareAndSwap(`sku`, 1, 6) {
+		t.Error("Expected CompareAndSwap() with the wrong old value to fail")
+	}
+	if trie.CompareAndSwap(`sk`, nil, 6) {
+		t.Error("Expected CompareAndSwap() of a non-member to fail")
+	}
+	if !trie.CompareAndSwap(`sku`, 2, 6) {
+		t.Error("Expected CompareAndSwap() to succeed")
+	}
+
+	increment := func(old interface{}, ok bool) interface{} {
+		if !ok {
+			return 1
+		}
+		return old.(int) + 1
+	}
+	if v := trie.Update(`sku`, increment); v != 7 {
+		t.Errorf("Expected Update() to return 7, got %v", v)
+	}
+	if v := trie.Update(`skate`, increment); v != 1 {
+		t.Errorf("Expected Update() of a new string to return 1, got %v", v)
+	}
+
+	if v, ok := trie.Delete(`sku`); !ok || v != 7 {
+		t.Errorf("Expected Delete() to return 7/true, got %v/%v", v, ok)
+	}
+	if v, ok := trie.Delete(`sku`); ok || v != nil {
+		t.Errorf("Expected a second Delete() to return <nil>/false, got %v/%v", v, ok)
+	}
+	if trie.Contains(`sku`) || trie.Len() != 3 {
+		t.Errorf("Expected 3 members after Delete(), got %v", *trie.Members())
+	}
+}
+
+func TestHasValue(t *testing.T) {
+	trie := NewTrie()
+	trie.AddString(`plain`)
+	trie.AddValue(`empty`, nil)
+
+	if trie.HasValue(`plain`) {
+		t.Error("Expected a string added by AddString() to have no value")
+	}
+	if !trie.HasValue(`empty`) {
+		t.Error("Expected a string added with a nil value to have one")
+	}
+	if trie.HasValue(`missing`) {
+		t.Error("Expected a non-member to have no value")
+	}
+
+	// adding the string again mustn't lose its value
+	trie.AddString(`empty`)
+	if !trie.HasValue(`empty`) {
+		t.Error("Expected AddString() to keep an existing value")
+	}
+
+	if trie.CompareAndSwap(`plain`, nil, 1) {
+		t.Error("Expected CompareAndSwap() of a member without a value to fail")
+	}
+	if !trie.CompareAndSwap(`empty`, nil, 1) {
+		t.Error("Expected CompareAndSwap() of a nil value to succeed")
+	}
+
+	// Put(), LoadOrStore() and Delete() all report membership, whether or not there's a value
+	if actual, loaded := trie.LoadOrStore(`plain`, 2); !loaded || actual != nil {
+		t.Errorf("Expected LoadOrStore() to load <nil> for a member without a value, got %v/%v", actual, loaded)
+	}
+	if trie.HasValue(`plain`) {
+		t.Error("Expected LoadOrStore() not to store a value for a member")
+	}
+	if _, existed := trie.Put(`plain`, 2); !existed || !trie.HasValue(`plain`) {
+		t.Error("Expected Put() to store a value for an existing member")
+	}
+
+	// removal forgets the value, even if the string is added again
+	trie.Remove(`plain`)
+	trie.AddString(`plain`)
+	if trie.HasValue(`plain`) {
+		t.Error("Expected a removed string to lose its value")
+	}
+}
@@ -0,0 +1,166 @@
+/*
+ * stream.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"unicode"
+	"utf8"
+)
+
+// Words longer than this many bytes are passed through a HyphenWriter without being hyphenated, so that
+// input without whitespace can't be buffered without limit.  Once a word passes this length, what has
+// been buffered of it is written out, and the rest of it is written as it arrives.
+const maxStreamWordLength = 1024
+
+// A HyphenWriter hyphenates the text written to it, passing the result on to another io.Writer.  Words
+// are delimited by whitespace, and only the word currently being written is buffered, along with any
+// incomplete UTF-8 sequence at the end of a write.  Close() must be called to write the final word.
+type HyphenWriter struct {
+	h       *Hyphenator
+	w       io.Writer
+	hyphen  string
+	partial []byte       // an incomplete UTF-8 sequence from the end of the last write.
+	word    bytes.Buffer // the word being written.
+	invalid bool         // whether the word contains invalid UTF-8, so can't be hyphenated.
+	long    bool         // whether the word is too long to buffer, so is being passed straight through.
+	out     bytes.Buffer // output waiting to be written.
+	err     os.Error     // the first error returned by the underlying writer.
+}
+
+// Creates and returns a new HyphenWriter, which hyphenates using h and writes the result to w with the
+// given hyphen string inserted at each break.
+func NewHyphenWriter(w io.Writer, h *Hyphenator, hyphen string) *HyphenWriter {
+	hw := new(HyphenWriter)
+	hw.h = h
+	hw.w = w
+	hw.hyphen = hyphen
+	return hw
+}
+
+// Internal function: moves the current word to the output, hyphenating it if possible.
+func (hw *HyphenWriter) endWord() {
+	if hw.word.Len() == 0 {
+		return
+	}
+
+	if hw.invalid {
+		hw.out.Write(hw.word.Bytes())
+	} else {
+		hw.out.WriteString(hw.h.Hyphenate(hw.word.String(), hw.hyphen))
+	}
+	hw.word.Reset()
+	hw.invalid = false
+}
+
+// Internal function: writes any waiting output to the underlying writer, remembering the first error.
+func (hw *HyphenWriter) flushOutput() os.Error {
+	_, hw.err = hw.w.Write(hw.out.Bytes())
+	hw.out.Reset()
+	return hw.err
+}
+
+// Hyphenates the text, writing all but the last word to the underlying writer.  All of p is consumed,
+// so the returned count is len(p), even if the underlying writer fails.  Once it has failed, nothing
+// more is consumed, and each later call returns 0 and the same error.
+func (hw *HyphenWriter) Write(p []byte) (n int, err os.Error) {
+	if hw.err != nil {
+		return 0, hw.err
+	}
+
+	data := p
+	if len(hw.partial) != 0 {
+		data = make([]byte, len(hw.partial)+len(p))
+		copy(data, hw.partial)
+		copy(data[len(hw.partial):], p)
+		hw.partial = nil
+	}
+
+	for i := 0; i < len(data); {
+		if !utf8.FullRune(data[i:]) {
+			// keep the start of the sequence for the next write
+			hw.partial = make([]byte, len(data)-i)
+			copy(hw.partial, data[i:])
+			break
+		}
+
+		rune, size := utf8.DecodeRune(data[i:])
+		switch {
+		case unicode.IsSpace(rune):
+			hw.endWord()
+			hw.long = false
+			hw.out.Write(data[i : i+size])
+		case hw.long:
+			hw.out.Write(data[i : i+size])
+		default:
+			if rune == utf8.RuneError && size == 1 {
+				hw.invalid = true
+			}
+			hw.word.Write(data[i : i+size])
+			if hw.word.Len() > maxStreamWordLength {
+				hw.out.Write(hw.word.Bytes())
+				hw.word.Reset()
+				hw.invalid = false
+				hw.long = true
+			}
+		}
+		i += size
+	}
+
+	return len(p), hw.flushOutput()
+}
+
+// Writes the final word, along with any incomplete UTF-8 sequence left at the end of the input.  The
+// underlying writer is not closed.  If it has already failed, its error is returned instead.
+func (hw *HyphenWriter) Close() os.Error {
+	if hw.err != nil {
+		return hw.err
+	}
+
+	if len(hw.partial) != 0 {
+		hw.word.Write(hw.partial)
+		hw.invalid = true
+		hw.partial = nil
+	}
+	hw.endWord()
+	hw.long = false
+	return hw.flushOutput()
+}
@@ -0,0 +1,142 @@
+/*
+ * stream_test.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"testing"
+	"bytes"
+	"os"
+)
+
+func TestHyphenWriter(t *testing.T) {
+	patterns := NewTrie()
+	patterns.AddPatternString(`hy3ph`)
+	patterns.AddPatternString(`hen5at`)
+	patterns.AddPatternString(`ü1b`)
+
+	input := []byte("The hyphenation of Hyphenation,\n  trüber hyphenation.")
+	expected := "The hy-phen-ation of Hy-phen-ation,\n  trü-ber hy-phen-ation."
+
+	// write a byte at a time, so words and UTF-8 sequences are split between writes
+	buf := new(bytes.Buffer)
+	hw := NewHyphenWriter(buf, NewHyphenator(patterns), `-`)
+	for i := range input {
+		if n, err := hw.Write(input[i : i+1]); n != 1 || err != nil {
+			t.Fatalf("Write failed: %d, %s", n, err)
+		}
+	}
+	if buf.String() != "The hy-phen-ation of Hy-phen-ation,\n  trü-ber " {
+		t.Errorf("Expected the last word to remain buffered, got '%s'", buf.String())
+	}
+
+	if err := hw.Close(); err != nil {
+		t.Fatalf("Close failed: %s", err)
+	}
+	if buf.String() != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, buf.String())
+	}
+
+	// invalid UTF-8 is passed through untouched
+	buf.Reset()
+	hw = NewHyphenWriter(buf, NewHyphenator(patterns), `-`)
+	hw.Write([]byte("hyphen\xffation \xc3"))
+	hw.Close()
+	if buf.String() != "hyphen\xffation \xc3" {
+		t.Errorf("Expected invalid UTF-8 to be unchanged, got %q", buf.String())
+	}
+}
+
+func TestHyphenWriterLongWord(t *testing.T) {
+	patterns := NewTrie()
+	patterns.AddPatternString(`hy3ph`)
+	patterns.AddPatternString(`hen5at`)
+
+	buf := new(bytes.Buffer)
+	hw := NewHyphenWriter(buf, NewHyphenator(patterns), `-`)
+	input := new(bytes.Buffer)
+	for input.Len() < 3*maxStreamWordLength {
+		input.WriteString(`hyphenation`)
+	}
+	long := input.Bytes()
+	for i := 0; i < len(long); i += 100 {
+		end := i + 100
+		if end > len(long) {
+			end = len(long)
+		}
+		hw.Write(long[i:end])
+		if hw.word.Len() > maxStreamWordLength {
+			t.Fatalf("Expected at most %d bytes to be buffered, got %d", maxStreamWordLength, hw.word.Len())
+		}
+	}
+	if !bytes.Equal(buf.Bytes(), long) {
+		t.Errorf("Expected the long word to be written out unchanged before it ended")
+	}
+
+	// words after the long one are hyphenated as usual
+	hw.Write([]byte(` hyphenation`))
+	hw.Close()
+	if s := buf.String()[len(long):]; s != ` hy-phen-ation` {
+		t.Errorf("Expected ' hy-phen-ation' after the long word, got '%s'", s)
+	}
+}
+
+// A writer which always fails.
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, os.Error) {
+	return 0, os.NewError(`write failed`)
+}
+
+func TestHyphenWriterError(t *testing.T) {
+	hw := NewHyphenWriter(failingWriter{}, NewHyphenator(NewTrie()), `-`)
+
+	// the input is consumed even though it can't be written, so mustn't be written again
+	if n, err := hw.Write([]byte(`one two`)); n != 7 || err == nil {
+		t.Errorf("Expected the write to consume 7 bytes and fail, got %d, %v", n, err)
+	}
+	if n, err := hw.Write([]byte(` three`)); n != 0 || err == nil {
+		t.Errorf("Expected a write after the failure to consume nothing, got %d, %v", n, err)
+	}
+	if hw.word.String() != `two` {
+		t.Errorf("Expected only 'two' to remain buffered, got '%s'", hw.word.String())
+	}
+	if err := hw.Close(); err == nil {
+		t.Error("Expected Close() to return the earlier error")
+	}
+}
@@ -0,0 +1,159 @@
+/*
+ * subtree.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+// Internal function: returns the nodes on the path from this node to the node for the given prefix,
+// beginning with this node, and the rune leading to each node after it.  If create is false and the
+// prefix has no node, the path is nil.
+func (p *Trie) path(prefix string, create bool) ([]*Trie, []int) {
+	runes := []int(prefix)
+	path := make([]*Trie, len(runes)+1)
+	path[0] = p
+	for i, rune := range runes {
+		child, ok := path[i].children[rune]
+		if !ok {
+			if !create {
+				return nil, nil
+			}
+			child = NewTrie()
+			path[i].insertChild(rune, child)
+		}
+		path[i+1] = child
+	}
+	return path, runes
+}
+
+// Removes every member string beginning with the given prefix, including the prefix itself.  Returns
+// the number of strings removed.
+func (p *Trie) DeletePrefix(prefix string) int {
+	path, runes := p.path(prefix, false)
+	if path == nil {
+		return 0
+	}
+
+	removed := path[len(path)-1].count
+	if len(runes) == 0 {
+		p.leaf, p.value, p.valued, p.count = false, nil, false, 0
+		p.children = make(map[int]*Trie)
+		p.runes, p.counts = nil, nil
+		return removed
+	}
+
+	for i, rune := range runes {
+		path[i].count -= removed
+		path[i].addCount(rune, -removed)
+	}
+
+	// prune the prefix's node, and any ancestors left with no members
+	for i := len(runes) - 1; i >= 0; i-- {
+		path[i].removeChild(runes[i])
+		if i == 0 || path[i].count != 0 {
+			break
+		}
+	}
+	return removed
+}
+
+// Returns a new trie containing the member strings beginning with the given prefix, with the prefix
+// removed, and their values.  The new trie is a copy, so changing it doesn't affect this one.
+func (p *Trie) SubTrie(prefix string) *Trie {
+	n := p.node(prefix)
+	if n == nil {
+		return NewTrie()
+	}
+	return n.copy()
+}
+
+// Internal function: adds the member strings of the other node to this one, in place, copying the
+// other's nodes wherever this one has none.  Returns the number of strings which weren't already
+// members.
+func (p *Trie) graft(other *Trie) (added int) {
+	if other.leaf {
+		if !p.leaf {
+			p.leaf = true
+			added++
+		}
+		if other.valued {
+			p.value, p.valued = other.value, true
+		}
+	}
+
+	inserted := false
+	for _, rune := range other.runes {
+		child, ok := p.children[rune]
+		if !ok {
+			child = other.children[rune].copy()
+			p.placeChild(rune, child)
+			added += child.count
+			inserted = true
+		} else if n := child.graft(other.children[rune]); n != 0 {
+			added += n
+			if !inserted {
+				p.addCount(rune, n)
+			}
+		}
+	}
+	if inserted {
+		p.rebuildCounts()
+	}
+
+	p.count += added
+	return
+}
+
+// Adds every member string of the other trie to this one, beneath the given prefix, along with its
+// value.  A string which is already a member takes its value from the other trie, if it has one there.
+// The nodes of the other trie are copied, not shared, and existing nodes are kept, so a Cursor held
+// in this trie remains valid.  This takes time proportional to the size of the other trie.
+func (p *Trie) Graft(prefix string, other *Trie) {
+	if other.count == 0 {
+		return
+	}
+	if other == p {
+		// the walk mustn't see the nodes it adds
+		other = other.copy()
+	}
+
+	path, runes := p.path(prefix, true)
+	added := path[len(path)-1].graft(other)
+	for i, rune := range runes {
+		path[i].count += added
+		path[i].addCount(rune, added)
+	}
+}
@@ -0,0 +1,138 @@
+/*
+ * subtree_test.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"testing"
+)
+
+func TestSubtrees(t *testing.T) {
+	trie := NewTrie()
+	trie.AddValue(`acme/a`, 1)
+	trie.AddValue(`acme/b`, 2)
+	trie.AddValue(`acme`, 3)
+	trie.AddValue(`ac`, 4)
+	trie.AddValue(`beta/a`, 5)
+
+	sub := trie.SubTrie(`acme`)
+	checkMembers("acme subtrie", sub, []string{``, `/a`, `/b`}, t)
+	if v, _ := sub.GetValue(`/b`); v != 2 || !sub.HasValue(`/b`) {
+		t.Errorf("Expected '/b' to have value 2, got %v", v)
+	}
+	sub.Remove(`/a`)
+	if !trie.Contains(`acme/a`) {
+		t.Error("Removing from the subtrie shouldn't change the original")
+	}
+	if trie.SubTrie(`zeta`).Len() != 0 {
+		t.Error("Expected the subtrie for a missing prefix to be empty")
+	}
+
+	if n := trie.DeletePrefix(`acme`); n != 3 {
+		t.Errorf("Expected to delete 3 strings, deleted %d", n)
+	}
+	checkMembers("trie after DeletePrefix(acme)", trie, []string{`ac`, `beta/a`}, t)
+	if trie.Size() != 8 {
+		t.Errorf("Expected 8 nodes after DeletePrefix(acme), got %d", trie.Size())
+	}
+	if n := trie.DeletePrefix(`acme`); n != 0 {
+		t.Errorf("Expected to delete nothing a second time, deleted %d", n)
+	}
+
+	// removing the only member below a node prunes it
+	if n := trie.DeletePrefix(`beta/`); n != 1 {
+		t.Errorf("Expected to delete 1 string, deleted %d", n)
+	}
+	if trie.Size() != 2 {
+		t.Errorf("Expected 2 nodes after DeletePrefix(beta/), got %d", trie.Size())
+	}
+
+	trie.Graft(`new/`, sub)
+	trie.AddValue(`ac/x`, 6)
+	trie.Graft(`ac/`, sub)
+	checkMembers("trie after Graft()", trie, []string{`ac`, `ac/`, `ac//b`, `ac/x`, `new/`, `new//b`}, t)
+	if n := trie.CountPrefix(`new`); n != 2 {
+		t.Errorf("Expected 2 strings beginning with 'new', got %d", n)
+	}
+	if v, _ := trie.GetValue(`ac/`); v != 3 {
+		t.Errorf("Expected 'ac/' to have value 3, got %v", v)
+	}
+
+	// grafting keeps the existing nodes, so a cursor below the prefix still sees the trie
+	cursor := trie.Cursor()
+	for _, rune := range `ac/` {
+		cursor.Step(rune)
+	}
+	other := NewTrie()
+	other.AddValue(`y`, 7)
+	other.AddValue(`x`, 8)
+	trie.Graft(`ac/`, other)
+	if !cursor.Step('y') || !cursor.IsLeaf() {
+		t.Error("Expected a cursor held below the prefix to see the grafted string")
+	}
+	if v, _ := trie.GetValue(`ac/x`); v != 8 {
+		t.Errorf("Expected 'ac/x' to take the grafted value 8, got %v", v)
+	}
+	if trie.Len() != 7 || trie.CountPrefix(`ac/`) != 4 {
+		t.Errorf("Expected 7 members, 4 beginning with 'ac/', got %v", *trie.Members())
+	}
+	if s, _, _ := trie.Select(4); s != `ac/y` || trie.Rank(`new/`) != 5 {
+		t.Errorf("Expected Select(4) to be 'ac/y' and Rank(new/) 5, got '%s' and %d", s, trie.Rank(`new/`))
+	}
+
+	// a trie may be grafted into itself
+	self := NewTrie()
+	self.AddString(`a`)
+	self.AddString(`ab`)
+	self.Graft(`a`, self)
+	checkMembers("trie grafted into itself", self, []string{`a`, `aa`, `aab`, `ab`}, t)
+
+	if n := trie.DeletePrefix(``); n != 7 || trie.Len() != 0 || trie.Size() != 0 {
+		t.Errorf("Expected the empty prefix to delete every string, deleted %d", n)
+	}
+
+	// deleting the root's own string forgets its value, even if it's added again
+	root := NewTrie()
+	root.AddValue(``, 1)
+	root.AddValue(`a`, 2)
+	root.DeletePrefix(``)
+	root.AddString(``)
+	if root.HasValue(``) {
+		t.Error("Expected the empty string to lose its value after DeletePrefix()")
+	}
+}
@@ -0,0 +1,107 @@
+/*
+ * syllable.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"container/vector"
+)
+
+// A Syllabifier splits words into syllables using hyphenation patterns.  Unlike a Hyphenator it applies
+// no minimum lengths, so every odd-valued position is a syllable boundary, including those which would
+// make typographically poor hyphens.
+type Syllabifier struct {
+	Patterns *Trie // the hyphenation patterns.
+
+	// If not nil, syllable patterns applied together with the hyphenation patterns, with the larger
+	// value taken at each position.  They may thus add boundaries, or suppress them with higher even values.
+	SyllablePatterns *Trie
+
+	Normalize bool // whether words are normalized, as by NormalizeWord(), before lookup.
+}
+
+// Creates and returns a new Syllabifier using the given hyphenation patterns.
+func NewSyllabifier(patterns *Trie) *Syllabifier {
+	s := new(Syllabifier)
+	s.Patterns = patterns
+	s.Normalize = true
+	return s
+}
+
+// Internal function: returns the rune offsets of the syllable boundaries in a word, without normalizing it.
+func (s *Syllabifier) boundaries(word string) *vector.IntVector {
+	values := s.Patterns.HyphenationValues(word)
+	if s.SyllablePatterns != nil {
+		extra := s.SyllablePatterns.HyphenationValues(word)
+		for i := 0; i < values.Len(); i++ {
+			values.Set(i, max(values.At(i), extra.At(i)))
+		}
+	}
+
+	points := new(vector.IntVector)
+	for i := 1; i < values.Len()-1; i++ {
+		if values.At(i)%2 == 1 {
+			points.Push(i)
+		}
+	}
+	return points
+}
+
+// Returns the rune offsets of the boundaries between the syllables of a word.
+func (s *Syllabifier) Points(word string) *vector.IntVector {
+	if !s.Normalize {
+		return s.boundaries(word)
+	}
+
+	normalized, origins := normalizeWord(word)
+	points := new(vector.IntVector)
+	boundaries := s.boundaries(normalized)
+	original := originOffsets(origins)
+	for i := 0; i < boundaries.Len(); i++ {
+		// there's no boundary within an expanded ligature or among reordered marks
+		if offset, ok := original(boundaries.At(i)); ok {
+			points.Push(offset)
+		}
+	}
+	return points
+}
+
+// Splits a word into its syllables.
+func (s *Syllabifier) Syllables(word string) *vector.StringVector {
+	return splitWord(word, s.Points(word))
+}
@@ -0,0 +1,77 @@
+/*
+ * syllable_test.go
+ * Trie
+ *
+ * Created on 18/10/2026.
+ *
+ * Copyright (c) 2026 the Trie contributors
+ * All rights reserved.
+ *
+ * Redistribution and use in source and binary forms, with or without
+ * modification, are permitted provided that the following conditions
+ * are met:
+ *
+ * Redistributions of source code must retain the above copyright notice,
+ * this list of conditions and the following disclaimer.
+ *
+ * Redistributions in binary form must reproduce the above copyright
+ * notice, this list of conditions and the following disclaimer in the
+ * documentation and/or other materials provided with the distribution.
+ *
+ * Neither the name of the project's author nor the names of its
+ * contributors may be used to endorse or promote products derived from
+ * this software without specific prior written permission.
+ *
+ * THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS
+ * "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT
+ * LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS
+ * FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT
+ * HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL,
+ * SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED
+ * TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+ * PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+ * LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+ * NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+ * SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+ *
+ */
+
+package trie
+
+import (
+	"testing"
+	"container/vector"
+)
+
+func checkSyllables(s *Syllabifier, word string, expected *vector.StringVector, t *testing.T) {
+	syllables := s.Syllables(word)
+	if syllables.Len() != expected.Len() {
+		t.Errorf("Expected syllables %v for '%s', got %v", *expected, word, *syllables)
+		return
+	}
+	for i := 0; i < syllables.Len(); i++ {
+		if syllables.At(i) != expected.At(i) {
+			t.Errorf("Expected syllables %v for '%s', got %v", *expected, word, *syllables)
+			return
+		}
+	}
+}
+
+func TestSyllabifier(t *testing.T) {
+	patterns := NewTrie()
+	patterns.AddPatternString(`hy3ph`)
+	patterns.AddPatternString(`hen5at`)
+	patterns.AddPatternString(`1tio`)
+	patterns.AddPatternString(`a1h`)
+
+	// there are no minimum lengths, unlike when hyphenating
+	s := NewSyllabifier(patterns)
+	checkSyllables(s, `ahoy`, &vector.StringVector{`a`, `hoy`}, t)
+	checkSyllables(s, `Hyphenation`, &vector.StringVector{`Hy`, `phen`, `a`, `tion`}, t)
+
+	// syllable patterns can both add and suppress boundaries
+	s.SyllablePatterns = NewTrie()
+	s.SyllablePatterns.AddPatternString(`n6a`)
+	s.SyllablePatterns.AddPatternString(`i1o`)
+	checkSyllables(s, `hyphenation`, &vector.StringVector{`hy`, `phena`, `ti`, `on`}, t)
+}
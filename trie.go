@@ -50,14 +50,17 @@ import (
 	"strings"
 	"container/vector"
 	"utf8"
-	"sort"
 )
 
 // A Trie uses runes rather than characters for indexing, therefore its child key values are integers.
 type Trie struct {
 	leaf     bool          // whether the node is a leaf (the end of an input string).
 	value    interface{}   // the value associated with the string up to this leaf node.
+	valued   bool          // whether a value has been stored at this leaf, even if it is nil.
 	children map[int]*Trie // a map of sub-tries for each child rune value.
+	runes    []int         // the rune values of the children, in ascending order.
+	count    int           // the number of leaf nodes at or below this node.
+	counts   []int         // a Fenwick tree of the children's leaf counts, indexed from 1 in rune order.
 }
 
 // Creates and returns a new Trie instance.
@@ -69,31 +72,122 @@ func NewTrie() *Trie {
 	return t
 }
 
+// Internal function: returns the index in the sorted child runes at which the given rune is found, or
+// would be inserted.
+func (p *Trie) runeIndex(rune int) int {
+	lo, hi := 0, len(p.runes)
+	for lo < hi {
+		mid := (lo + hi) / 2
+		if p.runes[mid] < rune {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// Internal function: makes room for one more child rune at the given index.
+func (p *Trie) growRunes(i int) {
+	n := len(p.runes)
+	if n == cap(p.runes) {
+		runes := make([]int, n, 2*n+1)
+		copy(runes, p.runes)
+		p.runes = runes
+	}
+	p.runes = p.runes[0 : n+1]
+	copy(p.runes[i+1:], p.runes[i:n])
+}
+
+// Internal function: adds a child node for a rune which has none.  The caller is responsible for this
+// node's own leaf count.
+func (p *Trie) insertChild(rune int, child *Trie) {
+	p.placeChild(rune, child)
+	p.rebuildCounts()
+}
+
+// Internal function: adds a child node for a rune which has none, in rune order, without updating the
+// leaf counts.  The caller must call rebuildCounts() once every child is added.
+func (p *Trie) placeChild(rune int, child *Trie) {
+	i := p.runeIndex(rune)
+	p.growRunes(i)
+	p.runes[i] = rune
+	p.children[rune] = child
+}
+
+// Internal function: adds a child node for a rune greater than that of any existing child.  Used when
+// building nodes in rune order; the caller must call rebuildCounts() once every child is added.
+func (p *Trie) appendChild(rune int, child *Trie) {
+	p.growRunes(len(p.runes))
+	p.runes[len(p.runes)-1] = rune
+	p.children[rune] = child
+}
+
+// Internal function: removes the child node for a rune.  The caller is responsible for this node's own
+// leaf count.
+func (p *Trie) removeChild(rune int) {
+	i := p.runeIndex(rune)
+	copy(p.runes[i:], p.runes[i+1:])
+	p.runes = p.runes[0 : len(p.runes)-1]
+	p.children[rune] = nil, false
+	p.rebuildCounts()
+}
+
+// Internal function: rebuilds the Fenwick tree of the children's leaf counts, in time proportional to
+// the number of children.
+func (p *Trie) rebuildCounts() {
+	if len(p.runes) == 0 {
+		p.counts = nil
+		return
+	}
+
+	p.counts = make([]int, len(p.runes)+1)
+	for i, rune := range p.runes {
+		k := i + 1
+		p.counts[k] += p.children[rune].count
+		if j := k + k&-k; j < len(p.counts) {
+			p.counts[j] += p.counts[k]
+		}
+	}
+}
+
+// Internal function: adds delta to the leaf count recorded for the child with the given rune.
+func (p *Trie) addCount(rune, delta int) {
+	for k := p.runeIndex(rune) + 1; k < len(p.counts); k += k & -k {
+		p.counts[k] += delta
+	}
+}
+
 // Internal function: adds items to the trie, reading runes from a strings.Reader.  It returns
 // the leaf node at which the addition ends.
 func (p *Trie) addRunes(r *strings.Reader) *Trie {
 	rune, _, err := r.ReadRune()
 	if err != nil {
-		p.leaf = true
+		if !p.leaf {
+			p.leaf = true
+			p.count++
+		}
 		return p
 	}
 
 	n := p.children[rune]
 	if n == nil {
 		n = NewTrie()
-		p.children[rune] = n
+		p.insertChild(rune, n)
 	}
 
-	// recurse to store sub-runes below the new node
-	return n.addRunes(r)
+	// recurse to store sub-runes below the new node, keeping the leaf counts up to date
+	before := n.count
+	leaf := n.addRunes(r)
+	if delta := n.count - before; delta != 0 {
+		p.count += delta
+		p.addCount(rune, delta)
+	}
+	return leaf
 }
 
 // Adds a string to the trie. If the string is already present, no additional storage happens. Yay!
 func (p *Trie) AddString(s string) {
-	if len(s) == 0 {
-		return
-	}
-
 	// append the runes to the trie -- we're ignoring the value in this invocation
 	p.addRunes(strings.NewReader(s))
 }
@@ -101,13 +195,9 @@ func (p *Trie) AddString(s string) {
 // Adds a string to the trie, with an associated value.  If the string is already present, only
 // the value is updated.
 func (p *Trie) AddValue(s string, v interface{}) {
-	if len(s) == 0 {
-		return
-	}
-
 	// append the runes to the trie
 	leaf := p.addRunes(strings.NewReader(s))
-	leaf.value = v
+	leaf.value, leaf.valued = v, true
 }
 
 // Internal string removal function.  Returns true if this node is empty following the removal.
@@ -115,26 +205,35 @@ func (p *Trie) removeRunes(r *strings.Reader) bool {
 	rune, _, err := r.ReadRune()
 	if err != nil {
 		// remove value, remove leaf flag
+		if p.leaf {
+			p.count--
+		}
 		p.value = nil
+		p.valued = false
 		p.leaf = false
 		return len(p.children) == 0
 	}
 
 	child, ok := p.children[rune]
-	if ok && child.removeRunes(r) {
-		// the child is now empty following the removal, so prune it
-		p.children[rune] = nil, false
+	if ok {
+		before := child.count
+		pruned := child.removeRunes(r)
+		delta := before - child.count
+		p.count -= delta
+		if pruned {
+			// the child is now empty following the removal, so prune it
+			p.removeChild(rune)
+		} else if delta != 0 {
+			p.addCount(rune, -delta)
+		}
 	}
 
-	return len(p.children) == 0
+	// a node which still ends a string mustn't be pruned
+	return !p.leaf && len(p.children) == 0
 }
 
 // Remove a string from the trie.  Returns true if the Trie is now empty.
 func (p *Trie) Remove(s string) bool {
-	if len(s) == 0 {
-		return len(p.children) == 0
-	}
-
 	// remove the runes, returning the final result
 	return p.removeRunes(strings.NewReader(s))
 }
@@ -160,19 +259,13 @@ func (p *Trie) includes(r *strings.Reader) *Trie {
 
 // Test for the inclusion of a particular string in the Trie.
 func (p *Trie) Contains(s string) bool {
-	if len(s) == 0 {
-		return false // empty strings can't be included (how could we add them?)
-	}
 	return p.includes(strings.NewReader(s)) != nil
 }
 
 // Return the value associated with the given string.  Double return: false if the given string was
-// not present, true if the string was present.  The value could be both valid and nil.
+// not present, true if the string was present.  The value could be both valid and nil; HasValue()
+// tells whether one was stored.
 func (p *Trie) GetValue(s string) (interface{}, bool) {
-	if len(s) == 0 {
-		return nil, false
-	}
-
 	leaf := p.includes(strings.NewReader(s))
 	if leaf == nil {
 		return nil, false
@@ -180,28 +273,32 @@ func (p *Trie) GetValue(s string) (interface{}, bool) {
 	return leaf.value, true
 }
 
-// Internal output-building function used by Members()
-func (p *Trie) buildMembers(prefix string) *vector.StringVector {
-	strList := new(vector.StringVector)
+// Test whether a value has been stored for the given string, by AddValue() or Put() for example.  A
+// string added only by AddString() is a member with no value, while one added with a nil value has one.
+func (p *Trie) HasValue(s string) bool {
+	leaf := p.includes(strings.NewReader(s))
+	return leaf != nil && leaf.valued
+}
 
+// Internal traversal function: calls f with each member string below this node and the leaf node at
+// which it ends.  Members are visited in no particular order.
+func (p *Trie) walk(prefix string, f func(string, *Trie)) {
 	if p.leaf {
-		strList.Push(prefix)
+		f(prefix, p)
 	}
 
-	// for each child, go grab all suffixes
 	for rune, child := range p.children {
-		buf := make([]byte, 4)
-		numChars := utf8.EncodeRune(rune, buf)
-		strList.AppendVector(child.buildMembers(prefix + string(buf[0:numChars])))
+		child.walk(prefix+string(rune), f)
 	}
-
-	return strList
 }
 
 // Retrieves all member strings, in order.
 func (p *Trie) Members() (members *vector.StringVector) {
-	members = p.buildMembers(``)
-	sort.Sort(members)
+	members = new(vector.StringVector)
+	p.each(new(keyBuffer), func(key string, value interface{}) bool {
+		members.Push(key)
+		return true
+	})
 	return
 }
 
@@ -216,9 +313,13 @@ func (p *Trie) Size() (sz int) {
 	return
 }
 
-// Return all anchored substrings of the given string within the Trie.
+// Return all anchored substrings of the given string within the Trie, including the empty string if it
+// is a member.
 func (p *Trie) AllSubstrings(s string) *vector.StringVector {
 	v := new(vector.StringVector)
+	if p.leaf {
+		v.Push(``)
+	}
 
 	for pos, rune := range s {
 		child, ok := p.children[rune]
@@ -229,7 +330,7 @@ func (p *Trie) AllSubstrings(s string) *vector.StringVector {
 
 		// if this is a leaf node, add the string so far to the output vector
 		if child.leaf {
-			v.Push(s[0:pos])
+			v.Push(s[0 : pos+utf8.RuneLen(rune)])
 		}
 
 		p = child
@@ -243,6 +344,10 @@ func (p *Trie) AllSubstrings(s string) *vector.StringVector {
 func (p *Trie) AllSubstringsAndValues(s string) (*vector.StringVector, *vector.Vector) {
 	sv := new(vector.StringVector)
 	vv := new(vector.Vector)
+	if p.leaf {
+		sv.Push(``)
+		vv.Push(p.value)
+	}
 	
 	for pos, rune := range s {
 		child, ok := p.children[rune]
@@ -128,6 +128,12 @@ func TestMultiFind(t *testing.T) {
 	if found.Len() != expected.Len() {
 		t.Errorf("expected %v but found %v", *expected, *found)
 	}
+	for i := 0; i < found.Len(); i++ {
+		if found.At(i) != expected.At(i) {
+			t.Errorf("Strings content mismatch: expected %v but found %v", *expected, *found)
+			break
+		}
+	}
 
 	expected.Cut(0, expected.Len())
 	expected.Push(`hen`)
@@ -137,6 +143,73 @@ func TestMultiFind(t *testing.T) {
 	if found.Len() != expected.Len() {
 		t.Errorf("expected %v but found %v", *expected, *found)
 	}
+	for i := 0; i < found.Len(); i++ {
+		if found.At(i) != expected.At(i) {
+			t.Errorf("Strings content mismatch: expected %v but found %v", *expected, *found)
+			break
+		}
+	}
+
+	// each substring ends with the whole of its last rune
+	trie.AddString(`hé`)
+	expected.Cut(0, expected.Len())
+	expected.Push(`hé`)
+	found = trie.AllSubstrings(`hélas`)
+	if found.Len() != expected.Len() {
+		t.Errorf("expected %v but found %v", *expected, *found)
+	}
+	for i := 0; i < found.Len(); i++ {
+		if found.At(i) != expected.At(i) {
+			t.Errorf("Strings content mismatch: expected %v but found %v", *expected, *found)
+			break
+		}
+	}
+}
+
+func TestEmptyString(t *testing.T) {
+	trie := NewTrie()
+	if trie.Contains(``) {
+		t.Error("a new trie shouldn't contain the empty string")
+	}
+
+	trie.AddValue(``, `default`)
+	trie.AddString(`a`)
+	trie.AddString(`ab`)
+	if !trie.Contains(``) {
+		t.Error("trie should contain the empty string")
+	}
+	if v, ok := trie.GetValue(``); !ok || v != `default` {
+		t.Errorf("Expected the empty string to have value 'default', got %v/%v", v, ok)
+	}
+	if trie.Size() != 2 || trie.Len() != 3 {
+		t.Errorf("Expected 2 nodes and 3 members, got %d and %d", trie.Size(), trie.Len())
+	}
+
+	mem := trie.Members()
+	if mem.Len() != 3 || mem.At(0) != `` {
+		t.Errorf("Expected the empty string to be the first member, got %v", *mem)
+	}
+	subs := trie.AllSubstrings(`abc`)
+	if subs.Len() != 3 || subs.At(0) != `` {
+		t.Errorf("Expected the empty string to be the first substring, got %v", *subs)
+	}
+
+	// removing a longer string mustn't remove its prefixes
+	if trie.Remove(`ab`) {
+		t.Error("trie shouldn't be empty after removing 'ab'")
+	}
+	if !trie.Contains(`a`) || !trie.Contains(``) {
+		t.Error("trie should still contain 'a' and the empty string after removing 'ab'")
+	}
+	if trie.Remove(`a`) {
+		t.Error("trie shouldn't be empty while it contains the empty string")
+	}
+	if !trie.Remove(``) {
+		t.Error("trie should be empty after removing the empty string")
+	}
+	if trie.Contains(``) || trie.Len() != 0 {
+		t.Error("trie shouldn't contain the empty string after removing it")
+	}
 }
 
 ///////////////////////////////////////////////////////////////
@@ -307,6 +380,26 @@ func TestMultiFindValue(t *testing.T) {
 	}
 }
 
+func TestHyphenate(t *testing.T) {
+	trie := NewTrie()
+	trie.AddPatternString(`hy3phe2n5a4t2io2n`)
+
+	values := trie.HyphenationValues(`hyphenation`)
+	if values.Len() != len(`hyphenation`)+1 {
+		t.Fatalf("Expected %d hyphenation values, got %d", len(`hyphenation`)+1, values.Len())
+	}
+	if values.At(2) != 3 || values.At(6) != 5 || values.At(7) != 4 {
+		t.Errorf("Unexpected hyphenation values %v", *values)
+	}
+
+	if s := trie.Hyphenate(`hyphenation`, `-`, DefaultLeftHyphenMin, DefaultRightHyphenMin); s != `hy-phen-ation` {
+		t.Errorf("Expected 'hy-phen-ation', got '%s'", s)
+	}
+	if s := trie.Hyphenate(`hyphenation`, `-`, 3, 5); s != `hyphen-ation` {
+		t.Errorf("Expected 'hyphen-ation' with a left minimum of 3, got '%s'", s)
+	}
+}
+
 //////////////////////////////////////////////////////////////////
 // Benchmarks
 // Run like so: